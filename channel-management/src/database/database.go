@@ -34,15 +34,89 @@ func Init(cfg *config.Config) {
 		str,
 	)
 	if err != nil {
-		slog.Error("Error has occured while connecting to DB", err)
+		slog.Error("Error has occured while connecting to DB", "error", err.Error())
 		panic(err)
 	}
 
-	db.AutoMigrate(&models.Channel{}, &models.UserChannel{}, &models.Admin{})
+	db.AutoMigrate(&models.Channel{}, &models.UserChannel{}, &models.Invite{}, &models.JoinRequest{})
+	migrateAdmins(db)
+	migrateMemberships(db)
+	migrateChannelSearch(db)
 	DB = db
 	slog.Info("Connected to DB")
 }
 
+// migrateChannelSearch adds the generated full-text document of channels and
+// the indexes behind channel search.
+func migrateChannelSearch(db *gorm.DB) {
+	statements := []string{
+		`ALTER TABLE channels ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(description, ''))) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_channels_search_vector ON channels USING GIN (search_vector)`,
+		`CREATE INDEX IF NOT EXISTS idx_channels_lower_name ON channels (lower(name) text_pattern_ops)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			slog.Error("Error has occured while migrating channel search", "error", err.Error())
+			panic(err)
+		}
+	}
+}
+
+// migrateAdmins folds the legacy admins table into member roles: creators
+// become owners and admins keep the admin role. The table is dropped after.
+func migrateAdmins(db *gorm.DB) {
+	if !db.HasTable("admins") {
+		return
+	}
+	slog.Info("Migrating channel admins to member roles")
+	tx := db.Begin()
+	err := tx.Exec(
+		`UPDATE user_channels SET role = ? FROM admins
+		WHERE admins.channel_id = user_channels.channel_id AND admins.user_id = user_channels.user_id
+		AND admins.deleted_at IS NULL`,
+		models.RoleAdmin,
+	).Error
+	if err == nil {
+		err = tx.Exec(
+			`UPDATE user_channels SET role = ? FROM channels
+			WHERE channels.id = user_channels.channel_id AND channels.creator_id = user_channels.user_id`,
+			models.RoleOwner,
+		).Error
+	}
+	if err == nil {
+		err = tx.DropTable("admins").Error
+	}
+	if err != nil {
+		tx.Rollback()
+		slog.Error("Error has occured while migrating admins", "error", err.Error())
+		panic(err)
+	}
+	tx.Commit()
+}
+
+// migrateMemberships makes a user a member of a channel at most once. Left
+// memberships are soft-deleted and do not count. Duplicates that concurrent
+// joins left behind are removed first, the oldest membership stays.
+func migrateMemberships(db *gorm.DB) {
+	err := db.Exec(
+		`UPDATE user_channels SET deleted_at = NOW()
+		WHERE deleted_at IS NULL AND id NOT IN (
+			SELECT MIN(id) FROM user_channels WHERE deleted_at IS NULL GROUP BY channel_id, user_id
+		)`,
+	).Error
+	if err == nil {
+		err = db.Exec(
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_user_channels_channel_user
+			ON user_channels (channel_id, user_id) WHERE deleted_at IS NULL`,
+		).Error
+	}
+	if err != nil {
+		slog.Error("Error has occured while migrating memberships", "error", err.Error())
+		panic(err)
+	}
+}
+
 func Close() {
 	slog.Info("Disconneting from DB")
 	DB.Close()
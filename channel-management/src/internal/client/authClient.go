@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 
 type AuthGRPCClient struct {
 	auth.AuthClient
+	serviceToken string
 }
 
 func NewAuthClient(cfg *config.Config) *AuthGRPCClient {
@@ -26,7 +28,17 @@ func NewAuthClient(cfg *config.Config) *AuthGRPCClient {
 	}
 	slog.Info("Connected to Auth")
 	slog.Info(connectionUrl)
-	return &AuthGRPCClient{auth.NewAuthClient(conn)}
+	return &AuthGRPCClient{auth.NewAuthClient(conn), cfg.Auth.ServiceToken}
+}
+
+// IsServiceCall reports whether a gRPC call carries the service token. The
+// check is off while no service token is configured.
+func (authClient *AuthGRPCClient) IsServiceCall(ctx context.Context) bool {
+	if authClient.serviceToken == "" {
+		return false
+	}
+	tokens := metadata.ValueFromIncomingContext(ctx, "x-service-token")
+	return len(tokens) == 1 && subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(authClient.serviceToken)) == 1
 }
 
 func (authClient *AuthGRPCClient) PerformAuthorize(ctx context.Context, r *http.Request, userId string) (*auth.AuthorizeResponse, error) {
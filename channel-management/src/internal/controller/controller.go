@@ -9,6 +9,8 @@ import (
 	"example.com/channel-management/src/internal/client"
 	"example.com/channel-management/src/internal/dto"
 	"example.com/channel-management/src/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ChannelManagementController struct {
@@ -77,7 +79,7 @@ func (c *ChannelManagementController) DeleteChannelHandler(w http.ResponseWriter
 	err = c.service.DeleteChannel(&channelReq)
 	if err != nil {
 		slog.Error("Failed to delete chat", "error", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -105,7 +107,7 @@ func (c *ChannelManagementController) UpdateChannelHandler(w http.ResponseWriter
 	err = c.service.UpdateChannel(&channelReq)
 	if err != nil {
 		slog.Error("Failed to update chat", "error", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -185,20 +187,10 @@ func (c *ChannelManagementController) InviteUserHandler(w http.ResponseWriter, r
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	isAdminReq := dto.IsAdminRequest{
-		ChannelId: inviteReq.ChannelId,
-		UserId:    inviteReq.UserId,
-	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", isAdminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
-		return
-	}
-
 	err = c.service.InviteUser(&inviteReq)
 	if err != nil {
 		slog.Error("Failed to invite user", "error", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -222,20 +214,10 @@ func (c *ChannelManagementController) KickUserHandler(w http.ResponseWriter, r *
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	isAdminReq := dto.IsAdminRequest{
-		ChannelId: kickReq.ChannelId,
-		UserId:    kickReq.UserId,
-	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", isAdminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
-		return
-	}
-
 	err = c.service.KickUser(&kickReq)
 	if err != nil {
 		slog.Error("Failed to kick user", "error", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -260,20 +242,10 @@ func (c *ChannelManagementController) MakeAdminHandler(w http.ResponseWriter, r
 		return
 	}
 
-	isAdminReq := dto.IsAdminRequest{
-		ChannelId: adminReq.ChannelId,
-		UserId:    adminReq.UserId,
-	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", adminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
-		return
-	}
-
 	err = c.service.MakeAdmin(&adminReq)
 	if err != nil {
 		slog.Error("Failed to make admin", "error", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -298,20 +270,10 @@ func (c *ChannelManagementController) DeleteAdminHandler(w http.ResponseWriter,
 		return
 	}
 
-	isAdminReq := dto.IsAdminRequest{
-		ChannelId: adminReq.ChannelId,
-		UserId:    adminReq.UserId,
-	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", adminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
-		return
-	}
-
 	err = c.service.DeleteAdmin(&adminReq)
 	if err != nil {
 		slog.Error("Failed to delete admin", "error", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -354,3 +316,381 @@ func (c *ChannelManagementController) GetChannelHandler(w http.ResponseWriter, r
 	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
 	w.Write(channelResp)
 }
+
+func (c *ChannelManagementController) ListUserChannelsHandler(w http.ResponseWriter, r *http.Request) {
+	var listReq dto.ListUserChannelsRequest
+	err := json.NewDecoder(r.Body).Decode(&listReq)
+	if err != nil {
+		slog.Error("Failed to decode request", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, listReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	channels, err := c.service.ListUserChannels(&listReq)
+	if err != nil {
+		slog.Error("Failed to list channels", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	channelsResp, err := json.Marshal(channels)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(channelsResp)
+}
+
+func (c *ChannelManagementController) SearchChannelsHandler(w http.ResponseWriter, r *http.Request) {
+	var searchReq dto.SearchChannelsRequest
+	err := json.NewDecoder(r.Body).Decode(&searchReq)
+	if err != nil {
+		slog.Error("Failed to decode request", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, searchReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	channels, err := c.service.SearchChannels(&searchReq)
+	if err != nil {
+		slog.Error("Failed to search channels", "error", err.Error())
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	channelsResp, err := json.Marshal(channels)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(channelsResp)
+}
+
+func (c *ChannelManagementController) SetMemberRoleHandler(w http.ResponseWriter, r *http.Request) {
+	var roleReq dto.SetMemberRoleRequest
+	err := json.NewDecoder(r.Body).Decode(&roleReq)
+	if err != nil {
+		slog.Error("Failed to decode request", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, roleReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	err = c.service.SetMemberRole(&roleReq)
+	if err != nil {
+		slog.Error("Failed to set member role", "error", err.Error())
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+}
+
+func (c *ChannelManagementController) CreateInviteHandler(w http.ResponseWriter, r *http.Request) {
+	var inviteReq dto.CreateInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&inviteReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, inviteReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	invite, err := c.service.CreateInvite(&inviteReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(invite)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChannelManagementController) RevokeInviteHandler(w http.ResponseWriter, r *http.Request) {
+	var revokeReq dto.RevokeInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&revokeReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, revokeReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	invite, err := c.service.RevokeInvite(&revokeReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(invite)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChannelManagementController) ListInvitesHandler(w http.ResponseWriter, r *http.Request) {
+	var listReq dto.ListInvitesRequest
+	err := json.NewDecoder(r.Body).Decode(&listReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, listReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	invites, err := c.service.ListInvites(&listReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(invites)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChannelManagementController) JoinByInviteHandler(w http.ResponseWriter, r *http.Request) {
+	var joinReq dto.JoinByInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&joinReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, joinReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	channelId, err := c.service.JoinByInvite(&joinReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(channelId.String())
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChannelManagementController) RequestJoinHandler(w http.ResponseWriter, r *http.Request) {
+	var joinReq dto.JoinChannelRequest
+	err := json.NewDecoder(r.Body).Decode(&joinReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, joinReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	request, err := c.service.RequestJoin(&joinReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(request)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChannelManagementController) ListJoinRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	var listReq dto.ListJoinRequestsRequest
+	err := json.NewDecoder(r.Body).Decode(&listReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, listReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	requests, err := c.service.ListJoinRequests(&listReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(requests)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChannelManagementController) ApproveJoinRequestHandler(w http.ResponseWriter, r *http.Request) {
+	var decideReq dto.DecideJoinRequestRequest
+	err := json.NewDecoder(r.Body).Decode(&decideReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, decideReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	request, err := c.service.ApproveJoinRequest(&decideReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(request)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChannelManagementController) RejectJoinRequestHandler(w http.ResponseWriter, r *http.Request) {
+	var decideReq dto.DecideJoinRequestRequest
+	err := json.NewDecoder(r.Body).Decode(&decideReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, decideReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	request, err := c.service.RejectJoinRequest(&decideReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(request)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+// httpStatus maps the gRPC status returned by the service to an HTTP status.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Internal:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -9,6 +9,7 @@ type CreateChannelRequest struct {
 	Name        string
 	Description string
 	CreatorId   uuid.UUID
+	Visibility  models.Visibility
 }
 
 type DeleteChannelRequest struct {
@@ -21,6 +22,8 @@ type UpdateChannelRequest struct {
 	Name        string
 	Description string
 	UserId      uuid.UUID
+	// Visibility is left unchanged when empty.
+	Visibility models.Visibility
 }
 
 type AdminRequest struct {
@@ -65,4 +68,100 @@ type GetChannelResponse struct {
 	Channel *models.Channel
 	Users   []string
 	Admins  []string
+	Members []ChannelMember
+}
+
+type ChannelMember struct {
+	UserId      uuid.UUID
+	Role        models.Role
+	Permissions []models.Permission
+}
+
+type SetMemberRoleRequest struct {
+	ChannelId    uuid.UUID
+	UserId       uuid.UUID
+	TargetUserId uuid.UUID
+	Role         models.Role
+}
+
+type CheckPermissionRequest struct {
+	ChannelId  uuid.UUID
+	UserId     uuid.UUID
+	Permission models.Permission
+}
+
+type ListUserChannelsRequest struct {
+	UserId    uuid.UUID
+	Limit     int
+	PageToken string
+}
+
+type ListUserChannelsResponse struct {
+	Channels      []*models.Channel
+	NextPageToken string
+}
+
+type SearchChannelsRequest struct {
+	UserId    uuid.UUID
+	Query     string
+	Limit     int
+	PageToken string
+}
+
+type SearchChannelsResponse struct {
+	Channels      []models.ChannelSearchResult
+	NextPageToken string
+}
+
+type CreateInviteRequest struct {
+	ChannelId uuid.UUID
+	UserId    uuid.UUID
+	ExpiresAt uint64
+	MaxUses   int
+}
+
+type RevokeInviteRequest struct {
+	Token  string
+	UserId uuid.UUID
+}
+
+type ListInvitesRequest struct {
+	ChannelId uuid.UUID
+	UserId    uuid.UUID
+}
+
+type JoinByInviteRequest struct {
+	Token  string
+	UserId uuid.UUID
+}
+
+type InviteResponse struct {
+	Token     string
+	ChannelId uuid.UUID
+	CreatorId uuid.UUID
+	ExpiresAt uint64
+	MaxUses   int
+	Uses      int
+	Revoked   bool
+}
+
+type ListJoinRequestsRequest struct {
+	ChannelId uuid.UUID
+	UserId    uuid.UUID
+}
+
+type DecideJoinRequestRequest struct {
+	ChannelId uuid.UUID
+	UserId    uuid.UUID
+	RequestId uint
+}
+
+type JoinRequestResponse struct {
+	RequestId uint
+	ChannelId uuid.UUID
+	UserId    uuid.UUID
+	Status    models.JoinRequestStatus
+	DecidedBy uuid.UUID
+	CreatedAt uint64
+	DecidedAt uint64
 }
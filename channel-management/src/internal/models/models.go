@@ -1,6 +1,8 @@
 package models
 
 import (
+	"slices"
+
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 )
@@ -9,17 +11,168 @@ type Channel struct {
 	Id          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
 	Name        string
 	Description string
-	CreatorId   uuid.UUID `gorm:"type:uuid"`
+	CreatorId   uuid.UUID  `gorm:"type:uuid"`
+	Visibility  Visibility `gorm:"default:'public'"`
 }
 
-type UserChannel struct {
+// ChannelSearchResult is a channel found by search along with its number of
+// subscribers.
+type ChannelSearchResult struct {
+	Channel
+	SubscriberCount int
+}
+
+// Visibility decides how users get into a channel. Public channels are
+// discoverable and can be joined directly, private ones only by invite and
+// approval ones through a join request reviewed by the admins.
+type Visibility string
+
+const (
+	VisibilityPublic   Visibility = "public"
+	VisibilityPrivate  Visibility = "private"
+	VisibilityApproval Visibility = "approval"
+)
+
+func (v Visibility) IsValid() bool {
+	return v == VisibilityPublic || v == VisibilityPrivate || v == VisibilityApproval
+}
+
+type JoinRequestStatus string
+
+const (
+	JoinRequestPending  JoinRequestStatus = "pending"
+	JoinRequestApproved JoinRequestStatus = "approved"
+	JoinRequestRejected JoinRequestStatus = "rejected"
+)
+
+// JoinRequest is a request to join an approval channel. DecidedBy and
+// DecidedAt are set once an admin approves or rejects it.
+type JoinRequest struct {
 	gorm.Model
-	ChannelId uuid.UUID `gorm:"type:uuid"`
-	UserId    uuid.UUID `gorm:"type:uuid"`
+	ChannelId uuid.UUID         `gorm:"type:uuid;index"`
+	UserId    uuid.UUID         `gorm:"type:uuid"`
+	Status    JoinRequestStatus `gorm:"default:'pending'"`
+	DecidedBy uuid.UUID         `gorm:"type:uuid"`
+	DecidedAt uint64
+}
+
+// JoinRequestDecidedEvent is published once a join request is approved or
+// rejected so that the notification service can tell the requester.
+type JoinRequestDecidedEvent struct {
+	RequestId   uint              `json:"requestId"`
+	ChannelId   uuid.UUID         `json:"channelId"`
+	ChannelName string            `json:"channelName"`
+	UserId      uuid.UUID         `json:"userId"`
+	Status      JoinRequestStatus `json:"status"`
+	DecidedAt   uint64            `json:"decidedAt"`
+}
+
+// Invite is a shareable link to join a channel. ExpiresAt and MaxUses are
+// unlimited when zero.
+type Invite struct {
+	gorm.Model
+	Token     string    `gorm:"unique_index"`
+	ChannelId uuid.UUID `gorm:"type:uuid;index"`
+	CreatorId uuid.UUID `gorm:"type:uuid"`
+	ExpiresAt uint64
+	MaxUses   int
+	Uses      int
+	Revoked   bool
+}
+
+func (i *Invite) IsExpired(now uint64) bool {
+	return i.ExpiresAt != 0 && now >= i.ExpiresAt
+}
+
+func (i *Invite) IsExhausted() bool {
+	return i.MaxUses != 0 && i.Uses >= i.MaxUses
 }
 
-type Admin struct {
+type UserChannel struct {
 	gorm.Model
 	ChannelId uuid.UUID `gorm:"type:uuid"`
 	UserId    uuid.UUID `gorm:"type:uuid"`
+	Role      Role      `gorm:"default:'member'"`
+}
+
+// Role is the rank of a member inside a channel. Roles are ordered, a member
+// can only manage members ranked below them.
+type Role string
+
+const (
+	RoleOwner     Role = "owner"
+	RoleAdmin     Role = "admin"
+	RoleModerator Role = "moderator"
+	RoleMember    Role = "member"
+	RoleReadOnly  Role = "read_only"
+)
+
+type Permission string
+
+const (
+	PermissionPost           Permission = "post"
+	PermissionInvite         Permission = "invite"
+	PermissionKick           Permission = "kick"
+	PermissionEditInfo       Permission = "edit_info"
+	PermissionPin            Permission = "pin"
+	PermissionEditMessages   Permission = "edit_messages"
+	PermissionDeleteMessages Permission = "delete_messages"
+	PermissionManageRoles    Permission = "manage_roles"
+	PermissionDeleteChannel  Permission = "delete_channel"
+	PermissionApproveJoins   Permission = "approve_joins"
+)
+
+// RolePermissions is the permission matrix of channel roles. Channels are
+// broadcasts, so plain members can not post.
+var RolePermissions = map[Role][]Permission{
+	RoleOwner: {
+		PermissionPost, PermissionInvite, PermissionKick, PermissionEditInfo, PermissionPin,
+		PermissionEditMessages, PermissionDeleteMessages, PermissionManageRoles, PermissionApproveJoins, PermissionDeleteChannel,
+	},
+	RoleAdmin: {
+		PermissionPost, PermissionInvite, PermissionKick, PermissionEditInfo, PermissionPin,
+		PermissionEditMessages, PermissionDeleteMessages, PermissionManageRoles, PermissionApproveJoins,
+	},
+	RoleModerator: {PermissionPost, PermissionInvite, PermissionKick, PermissionPin, PermissionDeleteMessages},
+	RoleMember:    {PermissionInvite},
+	RoleReadOnly:  {},
+}
+
+var roleRanks = map[Role]int{
+	RoleOwner:     4,
+	RoleAdmin:     3,
+	RoleModerator: 2,
+	RoleMember:    1,
+	RoleReadOnly:  0,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(RolePermissions[r], permission)
+}
+
+// Outranks reports whether r is ranked strictly above other.
+func (r Role) Outranks(other Role) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+func (r Role) IsAdmin() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// Successor picks the member that inherits the ownership of a channel: the
+// highest ranked one, the longest standing first. members must be ordered
+// by join time.
+func Successor(members []UserChannel) *UserChannel {
+	var successor *UserChannel
+	for i := range members {
+		if successor == nil || members[i].Role.Outranks(successor.Role) {
+			successor = &members[i]
+		}
+	}
+	return successor
 }
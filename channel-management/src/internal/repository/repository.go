@@ -1,19 +1,32 @@
 package repository
 
 import (
+	"context"
+	"errors"
+
 	"example.com/channel-management/src/internal/dto"
 	"example.com/channel-management/src/internal/models"
+	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type ChannelRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewChannelRepository(db *gorm.DB, redis *redis.Client) *ChannelRepository {
+	return &ChannelRepository{db: db, redis: redis}
 }
 
-func NewChannelRepository(db *gorm.DB) *ChannelRepository {
-	return &ChannelRepository{db: db}
+// PublishInJoinRequestDecidedChannel does nothing while Redis is not configured.
+func (r *ChannelRepository) PublishInJoinRequestDecidedChannel(message interface{}) error {
+	if r.redis == nil {
+		return nil
+	}
+	return r.redis.Publish(context.Background(), "join-request-decided-channel", message).Err()
 }
 
 func (r *ChannelRepository) FindById(channelId uuid.UUID) (*models.Channel, error) {
@@ -30,73 +43,268 @@ func (r *ChannelRepository) SaveChannel(channel *models.Channel) (uuid.UUID, err
 	return channel.Id, nil
 }
 
+// DeleteChannel removes the channel together with its invites and join
+// requests in one transaction.
 func (r *ChannelRepository) DeleteChannel(channelID uuid.UUID) error {
-	return r.db.Where("id = ?", channelID).Delete(&models.Channel{}).Error
+	tx := r.db.Begin()
+	if err := tx.Unscoped().Where("channel_id = ?", channelID).Delete(&models.UserChannel{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Unscoped().Where("channel_id = ?", channelID).Delete(&models.Invite{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Unscoped().Where("channel_id = ?", channelID).Delete(&models.JoinRequest{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", channelID).Delete(&models.Channel{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
 }
 
 func (r *ChannelRepository) UpdateChannel(channel *dto.UpdateChannelRequest) error {
-	return r.db.Model(&models.Channel{}).Where("id = ?", channel.ChannelId).Updates(map[string]interface{}{
+	updates := map[string]interface{}{
 		"name":        channel.Name,
 		"description": channel.Description,
-	}).Error
+	}
+	if channel.Visibility != "" {
+		updates["visibility"] = channel.Visibility
+	}
+	return r.db.Model(&models.Channel{}).Where("id = ?", channel.ChannelId).Updates(updates).Error
 }
 
+// AddUserToChannel fails with an error IsUniqueViolation recognizes when the
+// user is already a member.
 func (r *ChannelRepository) AddUserToChannel(user *models.UserChannel) error {
 	return r.db.Create(user).Error
 }
 
-func (r *ChannelRepository) RemoveUserFromChannel(user *models.UserChannel) error {
-	return r.db.Where("channel_id = ? AND user_id = ?", user.ChannelId, user.UserId).Delete(&models.UserChannel{}).Error
+// IsUniqueViolation reports whether err comes from a unique index, like the
+// one that keeps a user from joining a channel twice.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
 }
 
-func (r *ChannelRepository) AddAdmin(admin *models.Admin) error {
-	return r.db.Create(&admin).Error
+// RemoveUserFromChannel removes the membership. When the owner leaves, the
+// successor among the remaining members becomes the owner. It returns the
+// promoted user, uuid.Nil when nobody was promoted.
+func (r *ChannelRepository) RemoveUserFromChannel(user *models.UserChannel) (uuid.UUID, error) {
+	tx := r.db.Begin()
+	// Locking every membership serializes concurrent leaves of the same channel.
+	var members []models.UserChannel
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("channel_id = ?", user.ChannelId).Order("id").Find(&members).Error
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+	var leaving *models.UserChannel
+	remaining := make([]models.UserChannel, 0, len(members))
+	for i := range members {
+		if members[i].UserId == user.UserId {
+			leaving = &members[i]
+			continue
+		}
+		remaining = append(remaining, members[i])
+	}
+	if leaving == nil {
+		tx.Rollback()
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+
+	err = tx.Where("channel_id = ? AND user_id = ?", user.ChannelId, user.UserId).Delete(&models.UserChannel{}).Error
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+	promoted := uuid.Nil
+	if leaving.Role == models.RoleOwner && len(remaining) > 0 {
+		successor := models.Successor(remaining)
+		err = tx.Model(&models.UserChannel{}).Where("id = ?", successor.ID).Update("role", models.RoleOwner).Error
+		if err != nil {
+			tx.Rollback()
+			return uuid.Nil, err
+		}
+		promoted = successor.UserId
+	}
+	if err := tx.Commit().Error; err != nil {
+		return uuid.Nil, err
+	}
+	return promoted, nil
 }
 
-func (r *ChannelRepository) RemoveAdmin(admin *models.Admin) error {
-	return r.db.Where("channel_id = ? AND user_id = ?", admin.ChannelId, admin.UserId).Delete(&models.Admin{}).Error
+// GetMemberRole returns gorm.ErrRecordNotFound when the user is not a member.
+func (r *ChannelRepository) GetMemberRole(channelId uuid.UUID, userId uuid.UUID) (models.Role, error) {
+	var userChannel models.UserChannel
+	err := r.db.Where("channel_id = ? AND user_id = ?", channelId, userId).First(&userChannel).Error
+	return userChannel.Role, err
 }
 
-func (r *ChannelRepository) IsAdmin(admin *models.Admin) (bool, error) {
-	err := r.db.Where("channel_id = ? AND user_id = ?", admin.ChannelId, admin.UserId).First(&admin).Error
-	if err != nil {
-		return false, err
+func (r *ChannelRepository) SetMemberRole(channelId uuid.UUID, userId uuid.UUID, role models.Role) error {
+	result := r.db.Model(&models.UserChannel{}).Where("channel_id = ? AND user_id = ?", channelId, userId).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
-	return true, nil
+	return nil
 }
 
-func (r *ChannelRepository) GetChanUsers(channelId uuid.UUID) ([]string, error) {
+func (r *ChannelRepository) GetChannelMembers(channelId uuid.UUID) ([]models.UserChannel, error) {
 	var userChannels []models.UserChannel
-	err := r.db.Where("channel_id = ?", channelId).Find(&userChannels).Error
-	if err != nil {
-		return nil, err
-	}
+	err := r.db.Where("channel_id = ?", channelId).Order("id").Find(&userChannels).Error
+	return userChannels, err
+}
 
-	if len(userChannels) == 0 {
-		return nil, gorm.ErrRecordNotFound
-	}
+// GetUserChannels returns up to limit memberships of the user whose id is
+// greater than afterId, in membership order.
+func (r *ChannelRepository) GetUserChannels(userId uuid.UUID, afterId uint, limit int) ([]models.UserChannel, error) {
+	var userChannels []models.UserChannel
+	err := r.db.Where("user_id = ? AND id > ?", userId, afterId).Order("id").Limit(limit).Find(&userChannels).Error
+	return userChannels, err
+}
 
-	userIDs := make([]string, len(userChannels))
-	for i, uc := range userChannels {
-		userIDs[i] = uc.UserId.String()
-	}
-	return userIDs, nil
+func (r *ChannelRepository) FindByIds(channelIds []uuid.UUID) ([]models.Channel, error) {
+	var channels []models.Channel
+	err := r.db.Where("id IN (?)", channelIds).Find(&channels).Error
+	return channels, err
 }
 
-func (r *ChannelRepository) GetChannelAdmins(channelId uuid.UUID) ([]string, error) {
-	var admins []models.Admin
-	err := r.db.Where("channel_id = ?", channelId).Find(&admins).Error
+// SearchPublicChannels returns public channels whose lowercased name matches
+// namePattern or whose search document matches tsQuery. Name matches come
+// first, the rest is ordered by rank.
+func (r *ChannelRepository) SearchPublicChannels(namePattern string, tsQuery string, offset int, limit int) ([]models.ChannelSearchResult, error) {
+	var results []models.ChannelSearchResult
+	err := r.db.Raw(
+		`SELECT channels.id, channels.name, channels.description, channels.creator_id, channels.visibility,
+			(SELECT count(*) FROM user_channels
+				WHERE user_channels.channel_id = channels.id AND user_channels.deleted_at IS NULL) AS subscriber_count
+		FROM channels
+		WHERE channels.visibility = ?
+			AND (lower(channels.name) LIKE ? OR channels.search_vector @@ to_tsquery('simple', ?))
+		ORDER BY lower(channels.name) LIKE ? DESC,
+			ts_rank(channels.search_vector, to_tsquery('simple', ?)) DESC,
+			channels.id
+		OFFSET ? LIMIT ?`,
+		models.VisibilityPublic, namePattern, tsQuery, namePattern, tsQuery, offset, limit,
+	).Scan(&results).Error
+	return results, err
+}
+
+func (r *ChannelRepository) SaveInvite(invite *models.Invite) error {
+	return r.db.Create(invite).Error
+}
+
+func (r *ChannelRepository) FindInvite(token string) (*models.Invite, error) {
+	var invite models.Invite
+	err := r.db.Where("token = ?", token).First(&invite).Error
+	return &invite, err
+}
+
+func (r *ChannelRepository) GetChannelInvites(channelId uuid.UUID) ([]models.Invite, error) {
+	var invites []models.Invite
+	err := r.db.Where("channel_id = ?", channelId).Order("id").Find(&invites).Error
+	return invites, err
+}
+
+func (r *ChannelRepository) RevokeInvite(invite *models.Invite) error {
+	err := r.db.Model(&models.Invite{}).Where("id = ?", invite.ID).Update("revoked", true).Error
 	if err != nil {
-		return nil, err
+		return err
 	}
+	invite.Revoked = true
+	return nil
+}
 
-	if len(admins) == 0 {
-		return nil, gorm.ErrRecordNotFound
+// JoinByInvite consumes one use of the invite and adds the membership in one
+// transaction. The use is only counted while the invite is still valid at
+// now, otherwise gorm.ErrRecordNotFound is returned.
+func (r *ChannelRepository) JoinByInvite(invite *models.Invite, user *models.UserChannel, now uint64) error {
+	tx := r.db.Begin()
+	result := tx.Model(&models.Invite{}).
+		Where("id = ? AND revoked = false", invite.ID).
+		Where("expires_at = 0 OR expires_at > ?", now).
+		Where("max_uses = 0 OR uses < max_uses").
+		Update("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+	if err := tx.Create(user).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r *ChannelRepository) SaveJoinRequest(request *models.JoinRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *ChannelRepository) FindPendingJoinRequest(channelId uuid.UUID, userId uuid.UUID) (*models.JoinRequest, error) {
+	var request models.JoinRequest
+	err := r.db.Where("channel_id = ? AND user_id = ? AND status = ?", channelId, userId, models.JoinRequestPending).
+		First(&request).Error
+	return &request, err
+}
 
-	userIds := make([]string, len(admins))
-	for i, a := range admins {
-		userIds[i] = a.UserId.String()
+func (r *ChannelRepository) FindJoinRequest(channelId uuid.UUID, requestId uint) (*models.JoinRequest, error) {
+	var request models.JoinRequest
+	err := r.db.Where("channel_id = ? AND id = ?", channelId, requestId).First(&request).Error
+	return &request, err
+}
+
+// GetPendingJoinRequests returns the pending requests of the channel, oldest
+// first.
+func (r *ChannelRepository) GetPendingJoinRequests(channelId uuid.UUID) ([]models.JoinRequest, error) {
+	var requests []models.JoinRequest
+	err := r.db.Where("channel_id = ? AND status = ?", channelId, models.JoinRequestPending).Order("id").Find(&requests).Error
+	return requests, err
+}
+
+// DecideJoinRequest records the decision on a pending request and, when member
+// is set, adds the membership in one transaction. A request that is no longer
+// pending yields gorm.ErrRecordNotFound.
+func (r *ChannelRepository) DecideJoinRequest(request *models.JoinRequest, member *models.UserChannel) error {
+	tx := r.db.Begin()
+	result := tx.Model(&models.JoinRequest{}).
+		Where("id = ? AND status = ?", request.ID, models.JoinRequestPending).
+		Updates(map[string]interface{}{
+			"status":     request.Status,
+			"decided_by": request.DecidedBy,
+			"decided_at": request.DecidedAt,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+	if member != nil {
+		// The requester may have joined by invite in the meantime.
+		var count int
+		err := tx.Model(&models.UserChannel{}).Where("channel_id = ? AND user_id = ?", member.ChannelId, member.UserId).Count(&count).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if count == 0 {
+			if err := tx.Create(member).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
 	}
-	return userIds, nil
+	return tx.Commit().Error
 }
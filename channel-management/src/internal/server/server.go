@@ -11,6 +11,7 @@ import (
 	"example.com/channel-management/src/internal/client"
 	"example.com/channel-management/src/internal/controller"
 	"example.com/channel-management/src/internal/dto"
+	"example.com/channel-management/src/internal/models"
 	"example.com/channel-management/src/internal/service"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
@@ -37,6 +38,17 @@ func (h *HttpServer) StartServer() {
 	http.HandleFunc("POST /admin", h.channelMgmtController.MakeAdminHandler)
 	http.HandleFunc("DELETE /admin", h.channelMgmtController.DeleteAdminHandler)
 	http.HandleFunc("GET /channel", h.channelMgmtController.GetChannelHandler)
+	http.HandleFunc("GET /channels", h.channelMgmtController.ListUserChannelsHandler)
+	http.HandleFunc("GET /channels/search", h.channelMgmtController.SearchChannelsHandler)
+	http.HandleFunc("PUT /role", h.channelMgmtController.SetMemberRoleHandler)
+	http.HandleFunc("POST /invites", h.channelMgmtController.CreateInviteHandler)
+	http.HandleFunc("DELETE /invites", h.channelMgmtController.RevokeInviteHandler)
+	http.HandleFunc("GET /invites", h.channelMgmtController.ListInvitesHandler)
+	http.HandleFunc("POST /join/invite", h.channelMgmtController.JoinByInviteHandler)
+	http.HandleFunc("POST /join/request", h.channelMgmtController.RequestJoinHandler)
+	http.HandleFunc("GET /join/requests", h.channelMgmtController.ListJoinRequestsHandler)
+	http.HandleFunc("POST /join/requests/approve", h.channelMgmtController.ApproveJoinRequestHandler)
+	http.HandleFunc("POST /join/requests/reject", h.channelMgmtController.RejectJoinRequestHandler)
 }
 
 type GRPCServer struct {
@@ -80,6 +92,7 @@ func (s *GRPCServer) CreateChannel(ctx context.Context, req *channelMgmt.CreateC
 		Name:        req.Name,
 		Description: req.Description,
 		CreatorId:   creatorId,
+		Visibility:  models.Visibility(req.Visibility),
 	}
 	channelID, err := s.service.CreateChannel(createReq)
 	if err != nil {
@@ -106,6 +119,7 @@ func (s *GRPCServer) CreateChannel(ctx context.Context, req *channelMgmt.CreateC
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 	}, nil
 }
@@ -164,6 +178,7 @@ func (s *GRPCServer) UpdateChannel(ctx context.Context, req *channelMgmt.UpdateC
 		Name:        req.Name,
 		UserId:      userId,
 		Description: req.Description,
+		Visibility:  models.Visibility(req.Visibility),
 	}
 	err = s.service.UpdateChannel(updateReq)
 	if err != nil {
@@ -185,6 +200,7 @@ func (s *GRPCServer) UpdateChannel(ctx context.Context, req *channelMgmt.UpdateC
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 	}, nil
 }
@@ -231,6 +247,7 @@ func (s *GRPCServer) JoinChannel(ctx context.Context, req *channelMgmt.JoinChann
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 	}, nil
 }
@@ -277,6 +294,7 @@ func (s *GRPCServer) LeaveChannel(ctx context.Context, req *channelMgmt.LeaveCha
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 	}, nil
 }
@@ -329,6 +347,7 @@ func (s *GRPCServer) InviteUser(ctx context.Context, req *channelMgmt.InviteUser
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 	}, nil
 }
@@ -381,11 +400,12 @@ func (s *GRPCServer) KickUser(ctx context.Context, req *channelMgmt.KickUserRequ
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 	}, nil
 }
 
-func (s *GRPCServer) MakeAdmin(ctx context.Context, req *channelMgmt.MakeAdminRequest) (*channelMgmt.ChannelResponse, error) {
+func (s *GRPCServer) MakeChannelAdmin(ctx context.Context, req *channelMgmt.MakeAdminRequest) (*channelMgmt.ChannelResponse, error) {
 	slog.Info("MakeAdmin controller started")
 	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
@@ -425,7 +445,7 @@ func (s *GRPCServer) MakeAdmin(ctx context.Context, req *channelMgmt.MakeAdminRe
 	}
 	channel, err := s.service.GetChannel(getReq)
 	if err != nil {
-		slog.Error("GetChatWithAdmins error", "error", err.Error())
+		slog.Error("GetChannel error", "error", err.Error())
 		return nil, err
 	}
 	return &channelMgmt.ChannelResponse{
@@ -433,12 +453,14 @@ func (s *GRPCServer) MakeAdmin(ctx context.Context, req *channelMgmt.MakeAdminRe
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 		AdminsIds:       channel.Admins,
+		Members:         mapChannelMembers(channel.Members),
 	}, nil
 }
 
-func (s *GRPCServer) DeleteAdmin(ctx context.Context, req *channelMgmt.DeleteAdminRequest) (*channelMgmt.ChannelResponse, error) {
+func (s *GRPCServer) DeleteChannelAdmin(ctx context.Context, req *channelMgmt.DeleteAdminRequest) (*channelMgmt.ChannelResponse, error) {
 	slog.Info("DeleteAdmin controller started")
 	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
@@ -478,7 +500,7 @@ func (s *GRPCServer) DeleteAdmin(ctx context.Context, req *channelMgmt.DeleteAdm
 	}
 	channel, err := s.service.GetChannel(getReq)
 	if err != nil {
-		slog.Error("GetChatWithAdmins error", "error", err.Error())
+		slog.Error("GetChannel error", "error", err.Error())
 		return nil, err
 	}
 	return &channelMgmt.ChannelResponse{
@@ -486,12 +508,14 @@ func (s *GRPCServer) DeleteAdmin(ctx context.Context, req *channelMgmt.DeleteAdm
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 		AdminsIds:       channel.Admins,
+		Members:         mapChannelMembers(channel.Members),
 	}, nil
 }
 
-func (s *GRPCServer) IsAdmin(ctx context.Context, req *channelMgmt.IsAdminRequest) (*channelMgmt.IsAdminResponse, error) {
+func (s *GRPCServer) IsChannelAdmin(ctx context.Context, req *channelMgmt.IsAdminRequest) (*channelMgmt.IsAdminResponse, error) {
 	slog.Info("IsAdmin controller started")
 	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
@@ -524,6 +548,82 @@ func (s *GRPCServer) IsAdmin(ctx context.Context, req *channelMgmt.IsAdminReques
 
 func (s *GRPCServer) GetChannel(ctx context.Context, req *channelMgmt.GetChannelRequest) (*channelMgmt.ChannelResponse, error) {
 	slog.Info("GetChannel controller started")
+	if !s.authClient.IsServiceCall(ctx) {
+		_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+			return nil, err
+		}
+	}
+
+	channelId, err := uuid.Parse(req.ChannelId)
+	if err != nil {
+		slog.Error("Invalid channel Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	getReq := &dto.GetChannelRequest{
+		ChannelId: channelId,
+	}
+	channel, err := s.service.GetChannel(getReq)
+	if err != nil {
+		slog.Error("GetChannel error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("GetChannel controller successful", "channelID", req.ChannelId)
+	return &channelMgmt.ChannelResponse{
+		ChannelId:       channel.Channel.Id.String(),
+		CreatorId:       channel.Channel.CreatorId.String(),
+		Name:            channel.Channel.Name,
+		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
+		ParticipantsIds: channel.Users,
+		AdminsIds:       channel.Admins,
+		Members:         mapChannelMembers(channel.Members),
+	}, nil
+}
+
+func (s *GRPCServer) ListUserChannels(ctx context.Context, req *channelMgmt.ListUserChannelsRequest) (*channelMgmt.ListUserChannelsResponse, error) {
+	slog.Info("ListUserChannels controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	listReq := &dto.ListUserChannelsRequest{
+		UserId:    userId,
+		Limit:     int(req.Limit),
+		PageToken: req.PageToken,
+	}
+	listResp, err := s.service.ListUserChannels(listReq)
+	if err != nil {
+		slog.Error("ListUserChannels error", "error", err.Error())
+		return nil, err
+	}
+	channels := make([]*channelMgmt.ChannelResponse, len(listResp.Channels))
+	for i, channel := range listResp.Channels {
+		channels[i] = &channelMgmt.ChannelResponse{
+			ChannelId:   channel.Id.String(),
+			CreatorId:   channel.CreatorId.String(),
+			Name:        channel.Name,
+			Description: channel.Description,
+			Visibility:  string(channel.Visibility),
+		}
+	}
+	slog.Info("ListUserChannels controller successful", "userID", req.UserId)
+	return &channelMgmt.ListUserChannelsResponse{
+		Channels:      channels,
+		NextPageToken: listResp.NextPageToken,
+	}, nil
+}
+
+func (s *GRPCServer) SetChannelMemberRole(ctx context.Context, req *channelMgmt.SetMemberRoleRequest) (*channelMgmt.ChannelResponse, error) {
+	slog.Info("SetChannelMemberRole controller started")
 	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
 		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
@@ -535,6 +635,199 @@ func (s *GRPCServer) GetChannel(ctx context.Context, req *channelMgmt.GetChannel
 		slog.Error("Invalid channel Id", "error", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	targetUserId, err := uuid.Parse(req.TargetUserId)
+	if err != nil {
+		slog.Error("Invalid target user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	roleReq := &dto.SetMemberRoleRequest{
+		ChannelId:    channelId,
+		UserId:       userId,
+		TargetUserId: targetUserId,
+		Role:         models.Role(req.Role),
+	}
+	err = s.service.SetMemberRole(roleReq)
+	if err != nil {
+		slog.Error("SetMemberRole error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("SetChannelMemberRole controller successful", "channelID", req.ChannelId, "targetUserID", req.TargetUserId)
+
+	getReq := &dto.GetChannelRequest{
+		ChannelId: channelId,
+	}
+	channel, err := s.service.GetChannel(getReq)
+	if err != nil {
+		slog.Error("GetChannel error", "error", err.Error())
+		return nil, err
+	}
+	return &channelMgmt.ChannelResponse{
+		ChannelId:       channel.Channel.Id.String(),
+		CreatorId:       channel.Channel.CreatorId.String(),
+		Name:            channel.Channel.Name,
+		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
+		ParticipantsIds: channel.Users,
+		AdminsIds:       channel.Admins,
+		Members:         mapChannelMembers(channel.Members),
+	}, nil
+}
+
+func (s *GRPCServer) CheckChannelPermission(ctx context.Context, req *channelMgmt.CheckPermissionRequest) (*channelMgmt.CheckPermissionResponse, error) {
+	slog.Info("CheckChannelPermission controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	channelId, err := uuid.Parse(req.ChannelId)
+	if err != nil {
+		slog.Error("Invalid channel Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	checkReq := &dto.CheckPermissionRequest{
+		ChannelId:  channelId,
+		UserId:     userId,
+		Permission: models.Permission(req.Permission),
+	}
+	allowed, err := s.service.CheckPermission(checkReq)
+	if err != nil {
+		slog.Error("CheckPermission error", "error", err.Error())
+		return nil, err
+	}
+	return &channelMgmt.CheckPermissionResponse{Allowed: allowed}, nil
+}
+
+func (s *GRPCServer) CreateChannelInvite(ctx context.Context, req *channelMgmt.CreateInviteRequest) (*channelMgmt.InviteResponse, error) {
+	slog.Info("CreateChannelInvite controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	channelId, err := uuid.Parse(req.ChannelId)
+	if err != nil {
+		slog.Error("Invalid channel Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	inviteReq := &dto.CreateInviteRequest{
+		ChannelId: channelId,
+		UserId:    userId,
+		ExpiresAt: req.ExpiresAt,
+		MaxUses:   int(req.MaxUses),
+	}
+	invite, err := s.service.CreateInvite(inviteReq)
+	if err != nil {
+		slog.Error("CreateInvite error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("CreateChannelInvite controller successful", "channelId", req.ChannelId)
+	return mapInvite(invite), nil
+}
+
+func (s *GRPCServer) RevokeChannelInvite(ctx context.Context, req *channelMgmt.RevokeInviteRequest) (*channelMgmt.InviteResponse, error) {
+	slog.Info("RevokeChannelInvite controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	revokeReq := &dto.RevokeInviteRequest{
+		Token:  req.Token,
+		UserId: userId,
+	}
+	invite, err := s.service.RevokeInvite(revokeReq)
+	if err != nil {
+		slog.Error("RevokeInvite error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("RevokeChannelInvite controller successful", "channelId", invite.ChannelId)
+	return mapInvite(invite), nil
+}
+
+func (s *GRPCServer) ListChannelInvites(ctx context.Context, req *channelMgmt.ListInvitesRequest) (*channelMgmt.ListInvitesResponse, error) {
+	slog.Info("ListChannelInvites controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	channelId, err := uuid.Parse(req.ChannelId)
+	if err != nil {
+		slog.Error("Invalid channel Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	listReq := &dto.ListInvitesRequest{
+		ChannelId: channelId,
+		UserId:    userId,
+	}
+	invites, err := s.service.ListInvites(listReq)
+	if err != nil {
+		slog.Error("ListInvites error", "error", err.Error())
+		return nil, err
+	}
+	listResp := &channelMgmt.ListInvitesResponse{
+		Invites: make([]*channelMgmt.InviteResponse, len(invites)),
+	}
+	for i, invite := range invites {
+		listResp.Invites[i] = mapInvite(invite)
+	}
+	return listResp, nil
+}
+
+func (s *GRPCServer) JoinChannelByInvite(ctx context.Context, req *channelMgmt.JoinByInviteRequest) (*channelMgmt.ChannelResponse, error) {
+	slog.Info("JoinChannelByInvite controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	joinReq := &dto.JoinByInviteRequest{
+		Token:  req.Token,
+		UserId: userId,
+	}
+	channelId, err := s.service.JoinByInvite(joinReq)
+	if err != nil {
+		slog.Error("JoinByInvite error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("JoinChannelByInvite controller successful", "channelId", channelId, "userId", req.UserId)
+
 	getReq := &dto.GetChannelRequest{
 		ChannelId: channelId,
 	}
@@ -543,13 +836,220 @@ func (s *GRPCServer) GetChannel(ctx context.Context, req *channelMgmt.GetChannel
 		slog.Error("GetChannel error", "error", err.Error())
 		return nil, err
 	}
-	slog.Info("GetChannel controller successful", "channelID", req.ChannelId)
 	return &channelMgmt.ChannelResponse{
 		ChannelId:       channel.Channel.Id.String(),
 		CreatorId:       channel.Channel.CreatorId.String(),
 		Name:            channel.Channel.Name,
 		Description:     channel.Channel.Description,
+		Visibility:      string(channel.Channel.Visibility),
 		ParticipantsIds: channel.Users,
 		AdminsIds:       channel.Admins,
+		Members:         mapChannelMembers(channel.Members),
 	}, nil
 }
+
+func mapInvite(invite dto.InviteResponse) *channelMgmt.InviteResponse {
+	return &channelMgmt.InviteResponse{
+		Token:     invite.Token,
+		ChannelId: invite.ChannelId.String(),
+		CreatorId: invite.CreatorId.String(),
+		ExpiresAt: invite.ExpiresAt,
+		MaxUses:   int32(invite.MaxUses),
+		Uses:      int32(invite.Uses),
+		Revoked:   invite.Revoked,
+	}
+}
+
+func (s *GRPCServer) RequestJoinChannel(ctx context.Context, req *channelMgmt.JoinChannelRequest) (*channelMgmt.JoinRequestResponse, error) {
+	slog.Info("RequestJoinChannel controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	channelId, err := uuid.Parse(req.ChannelId)
+	if err != nil {
+		slog.Error("Invalid channel Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	joinReq := &dto.JoinChannelRequest{
+		ChannelId: channelId,
+		UserId:    userId,
+	}
+	request, err := s.service.RequestJoin(joinReq)
+	if err != nil {
+		slog.Error("RequestJoin error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("RequestJoinChannel controller successful", "channelID", req.ChannelId, "requestID", request.RequestId)
+	return mapJoinRequest(request), nil
+}
+
+func (s *GRPCServer) ListChannelJoinRequests(ctx context.Context, req *channelMgmt.ListJoinRequestsRequest) (*channelMgmt.ListJoinRequestsResponse, error) {
+	slog.Info("ListChannelJoinRequests controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	channelId, err := uuid.Parse(req.ChannelId)
+	if err != nil {
+		slog.Error("Invalid channel Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	listReq := &dto.ListJoinRequestsRequest{
+		ChannelId: channelId,
+		UserId:    userId,
+	}
+	requests, err := s.service.ListJoinRequests(listReq)
+	if err != nil {
+		slog.Error("ListJoinRequests error", "error", err.Error())
+		return nil, err
+	}
+	listResp := &channelMgmt.ListJoinRequestsResponse{
+		Requests: make([]*channelMgmt.JoinRequestResponse, len(requests)),
+	}
+	for i, request := range requests {
+		listResp.Requests[i] = mapJoinRequest(request)
+	}
+	return listResp, nil
+}
+
+func (s *GRPCServer) ApproveChannelJoinRequest(ctx context.Context, req *channelMgmt.DecideJoinRequestRequest) (*channelMgmt.JoinRequestResponse, error) {
+	slog.Info("ApproveChannelJoinRequest controller started")
+	decideReq, err := s.parseDecideJoinRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.service.ApproveJoinRequest(decideReq)
+	if err != nil {
+		slog.Error("ApproveJoinRequest error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("ApproveChannelJoinRequest controller successful", "channelID", req.ChannelId, "requestID", req.RequestId)
+	return mapJoinRequest(request), nil
+}
+
+func (s *GRPCServer) RejectChannelJoinRequest(ctx context.Context, req *channelMgmt.DecideJoinRequestRequest) (*channelMgmt.JoinRequestResponse, error) {
+	slog.Info("RejectChannelJoinRequest controller started")
+	decideReq, err := s.parseDecideJoinRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.service.RejectJoinRequest(decideReq)
+	if err != nil {
+		slog.Error("RejectJoinRequest error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("RejectChannelJoinRequest controller successful", "channelID", req.ChannelId, "requestID", req.RequestId)
+	return mapJoinRequest(request), nil
+}
+
+func (s *GRPCServer) parseDecideJoinRequest(ctx context.Context, req *channelMgmt.DecideJoinRequestRequest) (*dto.DecideJoinRequestRequest, error) {
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	channelId, err := uuid.Parse(req.ChannelId)
+	if err != nil {
+		slog.Error("Invalid channel Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &dto.DecideJoinRequestRequest{
+		ChannelId: channelId,
+		UserId:    userId,
+		RequestId: uint(req.RequestId),
+	}, nil
+}
+
+func mapJoinRequest(request dto.JoinRequestResponse) *channelMgmt.JoinRequestResponse {
+	joinRequest := &channelMgmt.JoinRequestResponse{
+		RequestId: uint64(request.RequestId),
+		ChannelId: request.ChannelId.String(),
+		UserId:    request.UserId.String(),
+		Status:    string(request.Status),
+		CreatedAt: request.CreatedAt,
+		DecidedAt: request.DecidedAt,
+	}
+	if request.DecidedBy != uuid.Nil {
+		joinRequest.DecidedBy = request.DecidedBy.String()
+	}
+	return joinRequest
+}
+
+func (s *GRPCServer) SearchChannels(ctx context.Context, req *channelMgmt.SearchChannelsRequest) (*channelMgmt.SearchChannelsResponse, error) {
+	slog.Info("SearchChannels controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	searchReq := &dto.SearchChannelsRequest{
+		UserId:    userId,
+		Query:     req.Query,
+		Limit:     int(req.Limit),
+		PageToken: req.PageToken,
+	}
+	searchResp, err := s.service.SearchChannels(searchReq)
+	if err != nil {
+		slog.Error("SearchChannels error", "error", err.Error())
+		return nil, err
+	}
+	channels := make([]*channelMgmt.ChannelSearchResult, len(searchResp.Channels))
+	for i, channel := range searchResp.Channels {
+		channels[i] = &channelMgmt.ChannelSearchResult{
+			ChannelId:       channel.Id.String(),
+			CreatorId:       channel.CreatorId.String(),
+			Name:            channel.Name,
+			Description:     channel.Description,
+			Visibility:      string(channel.Visibility),
+			SubscriberCount: int32(channel.SubscriberCount),
+		}
+	}
+	slog.Info("SearchChannels controller successful", "userID", req.UserId)
+	return &channelMgmt.SearchChannelsResponse{
+		Channels:      channels,
+		NextPageToken: searchResp.NextPageToken,
+	}, nil
+}
+
+func mapChannelMembers(members []dto.ChannelMember) []*channelMgmt.ChannelMember {
+	channelMembers := make([]*channelMgmt.ChannelMember, len(members))
+	for i, member := range members {
+		permissions := make([]string, len(member.Permissions))
+		for j, permission := range member.Permissions {
+			permissions[j] = string(permission)
+		}
+		channelMembers[i] = &channelMgmt.ChannelMember{
+			UserId:      member.UserId.String(),
+			Role:        string(member.Role),
+			Permissions: permissions,
+		}
+	}
+	return channelMembers
+}
@@ -0,0 +1,46 @@
+package service
+
+import "testing"
+
+func TestPrefixTsQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "empty", query: "", want: ""},
+		{name: "punctuation only", query: " _%- ", want: ""},
+		{name: "one word", query: "Golang", want: "golang:*"},
+		{name: "several words", query: "  go  news daily ", want: "go:* & news:* & daily:*"},
+		{name: "tsquery operators", query: "cats & !dogs | (birds):*", want: "cats:* & dogs:* & birds:*"},
+		{name: "non latin", query: "Новости спорта", want: "новости:* & спорта:*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefixTsQuery(tt.query); got != tt.want {
+				t.Fatalf("prefixTsQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamePrefixPattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "plain", query: "Go News", want: "go news%"},
+		{name: "trimmed", query: "  go ", want: "go%"},
+		{name: "percent", query: "100%", want: `100\%%`},
+		{name: "underscore", query: "go_news", want: `go\_news%`},
+		{name: "backslash", query: `a\b`, want: `a\\b%`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := namePrefixPattern(tt.query); got != tt.want {
+				t.Fatalf("namePrefixPattern(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
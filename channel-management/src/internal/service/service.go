@@ -1,9 +1,18 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -12,6 +21,11 @@ import (
 	"example.com/channel-management/src/internal/repository"
 )
 
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
 type ChannelManagementService struct {
 	repo repository.ChannelRepository
 }
@@ -25,35 +39,95 @@ func New(repo repository.ChannelRepository) *ChannelManagementService {
 func (s *ChannelManagementService) GetChannel(getReq *dto.GetChannelRequest) (*dto.GetChannelResponse, error) {
 	slog.Info("GetChannel called", "channelID", getReq.ChannelId)
 	channel, err := s.repo.FindById(getReq.ChannelId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "channel not found")
+	}
 	if err != nil {
 		slog.Error("Failed to get channel", "error", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	users, err := s.repo.GetChanUsers(getReq.ChannelId)
+	userChannels, err := s.repo.GetChannelMembers(getReq.ChannelId)
 	if err != nil {
 		slog.Error("Failed to get channel users", "error", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	admins, err := s.repo.GetChannelAdmins(getReq.ChannelId)
-	if err != nil {
-		slog.Error("Failed to get channel users", "error", err.Error())
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	if len(userChannels) == 0 {
+		return nil, status.Error(codes.NotFound, "channel not found")
 	}
 	getResp := &dto.GetChannelResponse{
 		Channel: channel,
-		Users:   users,
-		Admins:  admins,
+		Users:   make([]string, 0, len(userChannels)),
+		Members: make([]dto.ChannelMember, 0, len(userChannels)),
+	}
+	for _, uc := range userChannels {
+		getResp.Users = append(getResp.Users, uc.UserId.String())
+		if uc.Role.IsAdmin() {
+			getResp.Admins = append(getResp.Admins, uc.UserId.String())
+		}
+		getResp.Members = append(getResp.Members, dto.ChannelMember{
+			UserId:      uc.UserId,
+			Role:        uc.Role,
+			Permissions: models.RolePermissions[uc.Role],
+		})
 	}
 	return getResp, nil
 }
 
+// authorize returns the role of userId in the channel when it grants
+// permission.
+func (s *ChannelManagementService) authorize(channelId uuid.UUID, userId uuid.UUID, permission models.Permission) (models.Role, error) {
+	role, err := s.repo.GetMemberRole(channelId, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Permission denied: not a member", "channelID", channelId, "userID", userId)
+		return "", status.Error(codes.PermissionDenied, "permission denied: not a member of the channel")
+	}
+	if err != nil {
+		slog.Error("Failed to get member role", "error", err.Error())
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	if !role.Can(permission) {
+		slog.Error("Permission denied", "channelID", channelId, "userID", userId, "role", role, "permission", permission)
+		return "", status.Errorf(codes.PermissionDenied, "permission denied: %s can not %s", role, permission)
+	}
+	return role, nil
+}
+
+// authorizeOver additionally requires the actor to outrank the target member.
+func (s *ChannelManagementService) authorizeOver(channelId uuid.UUID, userId uuid.UUID, targetUserId uuid.UUID, permission models.Permission) (models.Role, error) {
+	role, err := s.authorize(channelId, userId, permission)
+	if err != nil {
+		return "", err
+	}
+	targetRole, err := s.repo.GetMemberRole(channelId, targetUserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", status.Error(codes.NotFound, "user is not a member of the channel")
+	}
+	if err != nil {
+		slog.Error("Failed to get member role", "error", err.Error())
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	if !role.Outranks(targetRole) {
+		slog.Error("Permission denied: target is not ranked below", "channelID", channelId, "userID", userId, "targetUserID", targetUserId)
+		return "", status.Errorf(codes.PermissionDenied, "permission denied: %s can not manage %s", role, targetRole)
+	}
+	return role, nil
+}
+
 func (s *ChannelManagementService) CreateChannel(channelReq *dto.CreateChannelRequest) (string, error) {
-	slog.Info("CreateChannel called", "name", channelReq.Name, "description", channelReq.Description, "creatorID", channelReq.CreatorId)
+	slog.Info("CreateChannel called", "name", channelReq.Name, "description", channelReq.Description, "creatorID", channelReq.CreatorId, "visibility", channelReq.Visibility)
+	visibility := channelReq.Visibility
+	if visibility == "" {
+		visibility = models.VisibilityPublic
+	}
+	if !visibility.IsValid() {
+		return "", status.Errorf(codes.InvalidArgument, "unknown visibility %q", visibility)
+	}
 	channel := models.Channel{
 		Id:          uuid.New(),
 		Name:        channelReq.Name,
 		Description: channelReq.Description,
 		CreatorId:   channelReq.CreatorId,
+		Visibility:  visibility,
 	}
 	channelId, err := s.repo.SaveChannel(&channel)
 	if err != nil {
@@ -64,21 +138,13 @@ func (s *ChannelManagementService) CreateChannel(channelReq *dto.CreateChannelRe
 	userChannel := models.UserChannel{
 		ChannelId: channelId,
 		UserId:    channelReq.CreatorId,
+		Role:      models.RoleOwner,
 	}
 	err = s.repo.AddUserToChannel(&userChannel)
 	if err != nil {
 		slog.Error("Failed to add user to channel", "error", err.Error())
 		return "", status.Error(codes.InvalidArgument, err.Error())
 	}
-	admin := models.Admin{
-		ChannelId: channelId,
-		UserId:    channelReq.CreatorId,
-	}
-	err = s.repo.AddAdmin(&admin)
-	if err != nil {
-		slog.Error("Failed to add admin to channel", "error", err.Error())
-		return "", status.Error(codes.InvalidArgument, err.Error())
-	}
 
 	slog.Info("Channel created successfully", "channelID", channelId)
 	return channelId.String(), nil
@@ -86,10 +152,8 @@ func (s *ChannelManagementService) CreateChannel(channelReq *dto.CreateChannelRe
 
 func (s *ChannelManagementService) DeleteChannel(channelReq *dto.DeleteChannelRequest) error {
 	slog.Info("DeleteChannel called", "channelID", channelReq.ChannelId, "userID", channelReq.UserId)
-	isAdminReq := &dto.IsAdminRequest{ChannelId: channelReq.ChannelId, UserId: channelReq.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	if _, err := s.authorize(channelReq.ChannelId, channelReq.UserId, models.PermissionDeleteChannel); err != nil {
+		return err
 	}
 	err := s.repo.DeleteChannel(channelReq.ChannelId)
 	if err != nil {
@@ -101,11 +165,12 @@ func (s *ChannelManagementService) DeleteChannel(channelReq *dto.DeleteChannelRe
 }
 
 func (s *ChannelManagementService) UpdateChannel(channelReq *dto.UpdateChannelRequest) error {
-	slog.Info("UpdateChannel called", "channelID", channelReq.ChannelId, "name", channelReq.Name, "description", channelReq.Description, "userID", channelReq.UserId)
-	isAdminReq := &dto.IsAdminRequest{ChannelId: channelReq.ChannelId, UserId: channelReq.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	slog.Info("UpdateChannel called", "channelID", channelReq.ChannelId, "name", channelReq.Name, "description", channelReq.Description, "userID", channelReq.UserId, "visibility", channelReq.Visibility)
+	if channelReq.Visibility != "" && !channelReq.Visibility.IsValid() {
+		return status.Errorf(codes.InvalidArgument, "unknown visibility %q", channelReq.Visibility)
+	}
+	if _, err := s.authorize(channelReq.ChannelId, channelReq.UserId, models.PermissionEditInfo); err != nil {
+		return err
 	}
 	err := s.repo.UpdateChannel(channelReq)
 	if err != nil {
@@ -118,11 +183,29 @@ func (s *ChannelManagementService) UpdateChannel(channelReq *dto.UpdateChannelRe
 
 func (s *ChannelManagementService) JoinChannel(joinReq *dto.JoinChannelRequest) error {
 	slog.Info("JoinChannel called", "channelID", joinReq.ChannelId, "userID", joinReq.UserId)
+	channel, err := s.repo.FindById(joinReq.ChannelId)
+	if err != nil {
+		slog.Error("Failed to get channel", "error", err.Error())
+		return status.Error(codes.NotFound, err.Error())
+	}
+	switch channel.Visibility {
+	case models.VisibilityPrivate:
+		return status.Error(codes.PermissionDenied, "channel is private, join it by invite")
+	case models.VisibilityApproval:
+		return status.Error(codes.FailedPrecondition, "channel requires approval, send a join request")
+	}
+	if err := s.ensureNotMember(joinReq.ChannelId, joinReq.UserId); err != nil {
+		return err
+	}
 	userChannel := models.UserChannel{
 		ChannelId: joinReq.ChannelId,
 		UserId:    joinReq.UserId,
+		Role:      models.RoleMember,
+	}
+	err = s.repo.AddUserToChannel(&userChannel)
+	if repository.IsUniqueViolation(err) {
+		return status.Error(codes.AlreadyExists, "user is already a member of the channel")
 	}
-	err := s.repo.AddUserToChannel(&userChannel)
 	if err != nil {
 		slog.Error("Failed to join channel", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
@@ -131,111 +214,583 @@ func (s *ChannelManagementService) JoinChannel(joinReq *dto.JoinChannelRequest)
 	return nil
 }
 
+func (s *ChannelManagementService) ensureNotMember(channelId uuid.UUID, userId uuid.UUID) error {
+	_, err := s.repo.GetMemberRole(channelId, userId)
+	if err == nil {
+		return status.Error(codes.AlreadyExists, "user is already a member of the channel")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Failed to get member role", "error", err.Error())
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
 func (s *ChannelManagementService) LeaveChannel(leaveReq *dto.LeaveChannelRequest) error {
 	slog.Info("LeaveChannel called", "channelID", leaveReq.ChannelId, "userID", leaveReq.UserId)
 	userChannel := models.UserChannel{
 		ChannelId: leaveReq.ChannelId,
 		UserId:    leaveReq.UserId,
 	}
-	err := s.repo.RemoveUserFromChannel(&userChannel)
+	promoted, err := s.repo.RemoveUserFromChannel(&userChannel)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "user is not a member of the channel")
+	}
 	if err != nil {
 		slog.Error("Failed to leave channel", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	slog.Info("User left channel successfully", "channelID", leaveReq.ChannelId, "userID", leaveReq.UserId)
+	if promoted != uuid.Nil {
+		slog.Info("Ownership passed on to successor", "channelID", leaveReq.ChannelId, "userID", promoted)
+	}
 	return nil
 }
 
+// InviteUser adds RequestingUserId to the channel on behalf of UserId.
 func (s *ChannelManagementService) InviteUser(inviteReq *dto.InviteUserRequest) error {
 	slog.Info("InviteUser called", "channelID", inviteReq.ChannelId, "userID", inviteReq.UserId, "requestingUserID", inviteReq.RequestingUserId)
-	isAdminReq := &dto.IsAdminRequest{ChannelId: inviteReq.ChannelId, UserId: inviteReq.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	if _, err := s.authorize(inviteReq.ChannelId, inviteReq.UserId, models.PermissionInvite); err != nil {
+		return err
+	}
+	if err := s.ensureNotMember(inviteReq.ChannelId, inviteReq.RequestingUserId); err != nil {
+		return err
 	}
 	userChannel := models.UserChannel{
 		ChannelId: inviteReq.ChannelId,
 		UserId:    inviteReq.RequestingUserId,
+		Role:      models.RoleMember,
 	}
 	err := s.repo.AddUserToChannel(&userChannel)
+	if repository.IsUniqueViolation(err) {
+		return status.Error(codes.AlreadyExists, "user is already a member of the channel")
+	}
 	if err != nil {
 		slog.Error("Failed to invite user to channel", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
-	slog.Info("User invited to channel successfully", "channelID", inviteReq.ChannelId, "userID", inviteReq.UserId)
+	slog.Info("User invited to channel successfully", "channelID", inviteReq.ChannelId, "userID", inviteReq.RequestingUserId)
 	return nil
 }
 
+// KickUser removes RequestingUserId from the channel on behalf of UserId, who
+// must outrank them.
 func (s *ChannelManagementService) KickUser(kickReq *dto.KickUserRequest) error {
 	slog.Info("KickUser called", "channelID", kickReq.ChannelId, "userID", kickReq.UserId, "requestingUserID", kickReq.RequestingUserId)
-	isAdminReq := &dto.IsAdminRequest{ChannelId: kickReq.ChannelId, UserId: kickReq.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	if _, err := s.authorizeOver(kickReq.ChannelId, kickReq.UserId, kickReq.RequestingUserId, models.PermissionKick); err != nil {
+		return err
 	}
 	userChannel := models.UserChannel{
 		ChannelId: kickReq.ChannelId,
 		UserId:    kickReq.RequestingUserId,
 	}
-	err := s.repo.RemoveUserFromChannel(&userChannel)
+	_, err := s.repo.RemoveUserFromChannel(&userChannel)
 	if err != nil {
 		slog.Error("Failed to kick user from channel", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
-	slog.Info("User kicked from channel successfully", "channelID", kickReq.ChannelId, "userID", kickReq.UserId)
+	slog.Info("User kicked from channel successfully", "channelID", kickReq.ChannelId, "userID", kickReq.RequestingUserId)
 	return nil
 }
 
-func (s *ChannelManagementService) MakeAdmin(adminReq *dto.AdminRequest) error {
-	slog.Info("MakeAdmin called", "channelID", adminReq.ChannelId, "userID", adminReq.UserId, "requestingUserID", adminReq.RequestingUserId)
-	isAdminReq := &dto.IsAdminRequest{ChannelId: adminReq.ChannelId, UserId: adminReq.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+// SetMemberRole changes the role of TargetUserId. The actor must outrank both
+// the current and the new role of the target, so ownership can not be granted
+// this way.
+func (s *ChannelManagementService) SetMemberRole(roleReq *dto.SetMemberRoleRequest) error {
+	slog.Info("SetMemberRole called", "channelID", roleReq.ChannelId, "userID", roleReq.UserId, "targetUserID", roleReq.TargetUserId, "role", roleReq.Role)
+	if !roleReq.Role.IsValid() {
+		return status.Errorf(codes.InvalidArgument, "unknown role %q", roleReq.Role)
+	}
+	role, err := s.authorizeOver(roleReq.ChannelId, roleReq.UserId, roleReq.TargetUserId, models.PermissionManageRoles)
+	if err != nil {
+		return err
 	}
-	admin := models.Admin{
-		ChannelId: adminReq.ChannelId,
-		UserId:    adminReq.RequestingUserId,
+	if !role.Outranks(roleReq.Role) {
+		slog.Error("Permission denied: role is not ranked below", "channelID", roleReq.ChannelId, "userID", roleReq.UserId, "role", roleReq.Role)
+		return status.Errorf(codes.PermissionDenied, "permission denied: %s can not grant %s", role, roleReq.Role)
 	}
-	err := s.repo.AddAdmin(&admin)
+	err = s.repo.SetMemberRole(roleReq.ChannelId, roleReq.TargetUserId, roleReq.Role)
 	if err != nil {
-		slog.Error("Failed to add admin", "error", err.Error())
-		return status.Error(codes.InvalidArgument, err.Error())
+		slog.Error("Failed to set member role", "error", err.Error())
+		return status.Error(codes.Internal, err.Error())
 	}
-	slog.Info("Admin added successfully", "channelID", adminReq.ChannelId, "userID", adminReq.UserId)
+	slog.Info("Member role set successfully", "channelID", roleReq.ChannelId, "userID", roleReq.TargetUserId, "role", roleReq.Role)
 	return nil
 }
 
+// MakeAdmin grants RequestingUserId the admin role on behalf of UserId.
+func (s *ChannelManagementService) MakeAdmin(adminReq *dto.AdminRequest) error {
+	return s.SetMemberRole(&dto.SetMemberRoleRequest{
+		ChannelId:    adminReq.ChannelId,
+		UserId:       adminReq.UserId,
+		TargetUserId: adminReq.RequestingUserId,
+		Role:         models.RoleAdmin,
+	})
+}
+
+// DeleteAdmin demotes RequestingUserId to a plain member on behalf of UserId.
 func (s *ChannelManagementService) DeleteAdmin(adminReq *dto.AdminRequest) error {
-	slog.Info("DeleteAdmin called", "channelID", adminReq.ChannelId, "userID", adminReq.UserId, "requestingUserID", adminReq.RequestingUserId)
-	isAdminReq := &dto.IsAdminRequest{ChannelId: adminReq.ChannelId, UserId: adminReq.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	return s.SetMemberRole(&dto.SetMemberRoleRequest{
+		ChannelId:    adminReq.ChannelId,
+		UserId:       adminReq.UserId,
+		TargetUserId: adminReq.RequestingUserId,
+		Role:         models.RoleMember,
+	})
+}
+
+// IsAdmin reports whether the user is the owner or an admin of the channel.
+// Users outside the channel are not admins.
+func (s *ChannelManagementService) IsAdmin(adminReq *dto.IsAdminRequest) (bool, error) {
+	slog.Info("IsAdmin called", "channelID", adminReq.ChannelId, "userID", adminReq.UserId)
+	role, err := s.repo.GetMemberRole(adminReq.ChannelId, adminReq.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		slog.Error("Failed to check admin status", "error", err.Error())
+		return false, status.Error(codes.Internal, err.Error())
 	}
-	admin := models.Admin{
-		ChannelId: adminReq.ChannelId,
-		UserId:    adminReq.RequestingUserId,
+	return role.IsAdmin(), nil
+}
+
+// CheckPermission reports whether the user's role grants the permission.
+// Users outside the channel have no permissions.
+func (s *ChannelManagementService) CheckPermission(checkReq *dto.CheckPermissionRequest) (bool, error) {
+	slog.Info("CheckPermission called", "channelID", checkReq.ChannelId, "userID", checkReq.UserId, "permission", checkReq.Permission)
+	role, err := s.repo.GetMemberRole(checkReq.ChannelId, checkReq.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
 	}
-	err := s.repo.RemoveAdmin(&admin)
 	if err != nil {
-		slog.Error("Failed to remove admin", "error", err.Error())
-		return status.Error(codes.InvalidArgument, err.Error())
+		slog.Error("Failed to get member role", "error", err.Error())
+		return false, status.Error(codes.Internal, err.Error())
 	}
-	slog.Info("Admin removed successfully", "channelID", adminReq.ChannelId, "userID", adminReq.UserId)
-	return nil
+	return role.Can(checkReq.Permission), nil
 }
 
-func (s *ChannelManagementService) IsAdmin(adminReq *dto.IsAdminRequest) (bool, error) {
-	slog.Info("IsAdmin called", "channelID", adminReq.ChannelId, "userID", adminReq.UserId)
-	admin := models.Admin{
-		ChannelId: adminReq.ChannelId,
-		UserId:    adminReq.UserId,
+// ListUserChannels pages through the channels of a user in the order they
+// were joined. Participants are left out since channels can be large. The
+// page token is the last membership id of the previous page.
+func (s *ChannelManagementService) ListUserChannels(req *dto.ListUserChannelsRequest) (*dto.ListUserChannelsResponse, error) {
+	slog.Info("ListUserChannels called", "userID", req.UserId, "pageToken", req.PageToken)
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	var afterId uint64
+	if req.PageToken != "" {
+		var err error
+		afterId, err = strconv.ParseUint(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	userChannels, err := s.repo.GetUserChannels(req.UserId, uint(afterId), limit+1)
+	if err != nil {
+		slog.Error("Failed to get user channels", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	listResp := &dto.ListUserChannelsResponse{}
+	if len(userChannels) > limit {
+		userChannels = userChannels[:limit]
+		listResp.NextPageToken = strconv.FormatUint(uint64(userChannels[limit-1].ID), 10)
+	}
+	if len(userChannels) == 0 {
+		return listResp, nil
+	}
+
+	channelIds := make([]uuid.UUID, len(userChannels))
+	for i, uc := range userChannels {
+		channelIds[i] = uc.ChannelId
 	}
-	isAdmin, err := s.repo.IsAdmin(&admin)
+	channels, err := s.repo.FindByIds(channelIds)
 	if err != nil {
-		slog.Error("Failed to check admin status", "error", err.Error())
-		return false, status.Error(codes.InvalidArgument, err.Error())
+		slog.Error("Failed to get channels", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	channelsById := make(map[uuid.UUID]*models.Channel, len(channels))
+	for i := range channels {
+		channelsById[channels[i].Id] = &channels[i]
+	}
+	for _, channelId := range channelIds {
+		if channel, ok := channelsById[channelId]; ok {
+			listResp.Channels = append(listResp.Channels, channel)
+		}
+	}
+	return listResp, nil
+}
+
+// SearchChannels finds public channels by a case-insensitive name prefix or
+// by a full-text match of every query word against the name and description.
+// The page token is the offset of the next page.
+func (s *ChannelManagementService) SearchChannels(req *dto.SearchChannelsRequest) (*dto.SearchChannelsResponse, error) {
+	slog.Info("SearchChannels called", "userID", req.UserId, "query", req.Query, "pageToken", req.PageToken)
+	tsQuery := prefixTsQuery(req.Query)
+	if tsQuery == "" {
+		return nil, status.Error(codes.InvalidArgument, "search query must contain a letter or digit")
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	var offset uint64
+	if req.PageToken != "" {
+		var err error
+		offset, err = strconv.ParseUint(req.PageToken, 10, 32)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	channels, err := s.repo.SearchPublicChannels(namePrefixPattern(req.Query), tsQuery, int(offset), limit+1)
+	if err != nil {
+		slog.Error("Failed to search channels", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	searchResp := &dto.SearchChannelsResponse{Channels: channels}
+	if len(channels) > limit {
+		searchResp.Channels = channels[:limit]
+		searchResp.NextPageToken = strconv.FormatUint(offset+uint64(limit), 10)
+	}
+	return searchResp, nil
+}
+
+// prefixTsQuery turns the words of query into a tsquery that matches every
+// word as a prefix, so that results show up while typing. It is empty when
+// query has no letter or digit.
+func prefixTsQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// namePrefixPattern is a LIKE pattern for lowercase names starting with query.
+func namePrefixPattern(query string) string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query) + "%"
+}
+
+func newInviteToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func inviteResponse(invite *models.Invite) dto.InviteResponse {
+	return dto.InviteResponse{
+		Token:     invite.Token,
+		ChannelId: invite.ChannelId,
+		CreatorId: invite.CreatorId,
+		ExpiresAt: invite.ExpiresAt,
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		Revoked:   invite.Revoked,
+	}
+}
+
+// CreateInvite issues a shareable invite link on behalf of a member allowed
+// to invite. A zero ExpiresAt or MaxUses leaves the link unlimited.
+func (s *ChannelManagementService) CreateInvite(req *dto.CreateInviteRequest) (dto.InviteResponse, error) {
+	slog.Info("CreateInvite called", "channelID", req.ChannelId, "userID", req.UserId, "expiresAt", req.ExpiresAt, "maxUses", req.MaxUses)
+	if req.MaxUses < 0 {
+		return dto.InviteResponse{}, status.Error(codes.InvalidArgument, "max uses can not be negative")
+	}
+	if req.ExpiresAt != 0 && req.ExpiresAt <= uint64(time.Now().Unix()) {
+		return dto.InviteResponse{}, status.Error(codes.InvalidArgument, "expiry must be in the future")
+	}
+	if _, err := s.authorize(req.ChannelId, req.UserId, models.PermissionInvite); err != nil {
+		return dto.InviteResponse{}, err
+	}
+	if _, err := s.repo.FindById(req.ChannelId); err != nil {
+		slog.Error("Failed to get channel", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.NotFound, err.Error())
+	}
+
+	token, err := newInviteToken()
+	if err != nil {
+		slog.Error("Failed to generate invite token", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	invite := models.Invite{
+		Token:     token,
+		ChannelId: req.ChannelId,
+		CreatorId: req.UserId,
+		ExpiresAt: req.ExpiresAt,
+		MaxUses:   req.MaxUses,
+	}
+	err = s.repo.SaveInvite(&invite)
+	if err != nil {
+		slog.Error("Failed to save invite", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Invite created successfully", "channelID", req.ChannelId, "inviteID", invite.ID)
+	return inviteResponse(&invite), nil
+}
+
+// RevokeInvite disables an invite link. Links can be revoked by their creator
+// and by the channel admins.
+func (s *ChannelManagementService) RevokeInvite(req *dto.RevokeInviteRequest) (dto.InviteResponse, error) {
+	slog.Info("RevokeInvite called", "userID", req.UserId)
+	invite, err := s.repo.FindInvite(req.Token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.InviteResponse{}, status.Error(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		slog.Error("Failed to get invite", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if invite.CreatorId != req.UserId {
+		role, err := s.repo.GetMemberRole(invite.ChannelId, req.UserId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Error("Failed to get member role", "error", err.Error())
+			return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+		}
+		if !role.IsAdmin() {
+			slog.Error("Permission denied: can not revoke invite", "channelID", invite.ChannelId, "userID", req.UserId)
+			return dto.InviteResponse{}, status.Error(codes.PermissionDenied, "permission denied: only the creator or an admin can revoke an invite")
+		}
+	}
+	err = s.repo.RevokeInvite(invite)
+	if err != nil {
+		slog.Error("Failed to revoke invite", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Invite revoked successfully", "channelID", invite.ChannelId, "inviteID", invite.ID)
+	return inviteResponse(invite), nil
+}
+
+// ListInvites returns every invite link of the channel, revoked and expired ones
+// included, to members allowed to invite.
+func (s *ChannelManagementService) ListInvites(req *dto.ListInvitesRequest) ([]dto.InviteResponse, error) {
+	slog.Info("ListInvites called", "channelID", req.ChannelId, "userID", req.UserId)
+	if _, err := s.authorize(req.ChannelId, req.UserId, models.PermissionInvite); err != nil {
+		return nil, err
+	}
+	invites, err := s.repo.GetChannelInvites(req.ChannelId)
+	if err != nil {
+		slog.Error("Failed to get invites", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	invitesResp := make([]dto.InviteResponse, len(invites))
+	for i := range invites {
+		invitesResp[i] = inviteResponse(&invites[i])
+	}
+	return invitesResp, nil
+}
+
+// JoinByInvite adds the user to the channel of a valid invite and returns the
+// channel id. Members following a link again do not use it up.
+func (s *ChannelManagementService) JoinByInvite(req *dto.JoinByInviteRequest) (uuid.UUID, error) {
+	slog.Info("JoinByInvite called", "userID", req.UserId)
+	invite, err := s.repo.FindInvite(req.Token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, status.Error(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		slog.Error("Failed to get invite", "error", err.Error())
+		return uuid.Nil, status.Error(codes.Internal, err.Error())
+	}
+	now := uint64(time.Now().Unix())
+	switch {
+	case invite.Revoked:
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite has been revoked")
+	case invite.IsExpired(now):
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite has expired")
+	case invite.IsExhausted():
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite has been used up")
+	}
+
+	err = s.ensureNotMember(invite.ChannelId, req.UserId)
+	if status.Code(err) == codes.AlreadyExists {
+		return invite.ChannelId, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	userChannel := models.UserChannel{
+		ChannelId: invite.ChannelId,
+		UserId:    req.UserId,
+		Role:      models.RoleMember,
+	}
+	err = s.repo.JoinByInvite(invite, &userChannel, now)
+	if repository.IsUniqueViolation(err) {
+		// Joined concurrently through another request.
+		return invite.ChannelId, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Another request used up or revoked the invite in the meantime.
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite is no longer valid")
+	}
+	if err != nil {
+		slog.Error("Failed to join by invite", "error", err.Error())
+		return uuid.Nil, status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("User joined channel by invite", "channelID", invite.ChannelId, "userID", req.UserId, "inviteID", invite.ID)
+	return invite.ChannelId, nil
+}
+
+func joinRequestResponse(request *models.JoinRequest) dto.JoinRequestResponse {
+	return dto.JoinRequestResponse{
+		RequestId: request.ID,
+		ChannelId: request.ChannelId,
+		UserId:    request.UserId,
+		Status:    request.Status,
+		DecidedBy: request.DecidedBy,
+		CreatedAt: uint64(request.CreatedAt.Unix()),
+		DecidedAt: request.DecidedAt,
+	}
+}
+
+// RequestJoin queues a join request for an approval channel. Asking again
+// while a request is pending returns the pending one.
+func (s *ChannelManagementService) RequestJoin(joinReq *dto.JoinChannelRequest) (dto.JoinRequestResponse, error) {
+	slog.Info("RequestJoin called", "channelID", joinReq.ChannelId, "userID", joinReq.UserId)
+	channel, err := s.repo.FindById(joinReq.ChannelId)
+	if err != nil {
+		slog.Error("Failed to get channel", "error", err.Error())
+		return dto.JoinRequestResponse{}, status.Error(codes.NotFound, err.Error())
+	}
+	switch channel.Visibility {
+	case models.VisibilityPublic:
+		return dto.JoinRequestResponse{}, status.Error(codes.FailedPrecondition, "channel is public, join it directly")
+	case models.VisibilityPrivate:
+		return dto.JoinRequestResponse{}, status.Error(codes.PermissionDenied, "channel is private, join it by invite")
+	}
+	if err := s.ensureNotMember(joinReq.ChannelId, joinReq.UserId); err != nil {
+		return dto.JoinRequestResponse{}, err
+	}
+
+	request, err := s.repo.FindPendingJoinRequest(joinReq.ChannelId, joinReq.UserId)
+	if err == nil {
+		return joinRequestResponse(request), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Failed to get join request", "error", err.Error())
+		return dto.JoinRequestResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	request = &models.JoinRequest{
+		ChannelId: joinReq.ChannelId,
+		UserId:    joinReq.UserId,
+		Status:    models.JoinRequestPending,
+	}
+	err = s.repo.SaveJoinRequest(request)
+	if err != nil {
+		slog.Error("Failed to save join request", "error", err.Error())
+		return dto.JoinRequestResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Join request created successfully", "channelID", joinReq.ChannelId, "requestID", request.ID)
+	return joinRequestResponse(request), nil
+}
+
+// ListJoinRequests returns the pending join requests of the channel to the
+// members allowed to decide on them.
+func (s *ChannelManagementService) ListJoinRequests(listReq *dto.ListJoinRequestsRequest) ([]dto.JoinRequestResponse, error) {
+	slog.Info("ListJoinRequests called", "channelID", listReq.ChannelId, "userID", listReq.UserId)
+	if _, err := s.authorize(listReq.ChannelId, listReq.UserId, models.PermissionApproveJoins); err != nil {
+		return nil, err
+	}
+	requests, err := s.repo.GetPendingJoinRequests(listReq.ChannelId)
+	if err != nil {
+		slog.Error("Failed to get join requests", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	requestsResp := make([]dto.JoinRequestResponse, len(requests))
+	for i := range requests {
+		requestsResp[i] = joinRequestResponse(&requests[i])
+	}
+	return requestsResp, nil
+}
+
+func (s *ChannelManagementService) ApproveJoinRequest(decideReq *dto.DecideJoinRequestRequest) (dto.JoinRequestResponse, error) {
+	return s.decideJoinRequest(decideReq, models.JoinRequestApproved)
+}
+
+func (s *ChannelManagementService) RejectJoinRequest(decideReq *dto.DecideJoinRequestRequest) (dto.JoinRequestResponse, error) {
+	return s.decideJoinRequest(decideReq, models.JoinRequestRejected)
+}
+
+// decideJoinRequest settles a pending request, adding the requester as a
+// member when approved, and notifies the requester of the decision.
+func (s *ChannelManagementService) decideJoinRequest(decideReq *dto.DecideJoinRequestRequest, decision models.JoinRequestStatus) (dto.JoinRequestResponse, error) {
+	slog.Info("DecideJoinRequest called", "channelID", decideReq.ChannelId, "userID", decideReq.UserId, "requestID", decideReq.RequestId, "decision", decision)
+	if _, err := s.authorize(decideReq.ChannelId, decideReq.UserId, models.PermissionApproveJoins); err != nil {
+		return dto.JoinRequestResponse{}, err
+	}
+	channel, err := s.repo.FindById(decideReq.ChannelId)
+	if err != nil {
+		slog.Error("Failed to get channel", "error", err.Error())
+		return dto.JoinRequestResponse{}, status.Error(codes.NotFound, err.Error())
+	}
+	request, err := s.repo.FindJoinRequest(decideReq.ChannelId, decideReq.RequestId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.JoinRequestResponse{}, status.Error(codes.NotFound, "join request not found")
+	}
+	if err != nil {
+		slog.Error("Failed to get join request", "error", err.Error())
+		return dto.JoinRequestResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if request.Status != models.JoinRequestPending {
+		return dto.JoinRequestResponse{}, status.Errorf(codes.FailedPrecondition, "join request is already %s", request.Status)
+	}
+
+	request.Status = decision
+	request.DecidedBy = decideReq.UserId
+	request.DecidedAt = uint64(time.Now().Unix())
+	var member *models.UserChannel
+	if decision == models.JoinRequestApproved {
+		member = &models.UserChannel{
+			ChannelId: request.ChannelId,
+			UserId:    request.UserId,
+			Role:      models.RoleMember,
+		}
+	}
+	err = s.repo.DecideJoinRequest(request, member)
+	if repository.IsUniqueViolation(err) {
+		return dto.JoinRequestResponse{}, status.Error(codes.AlreadyExists, "user is already a member of the channel")
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.JoinRequestResponse{}, status.Error(codes.FailedPrecondition, "join request has already been decided")
+	}
+	if err != nil {
+		slog.Error("Failed to decide join request", "error", err.Error())
+		return dto.JoinRequestResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Join request decided successfully", "channelID", request.ChannelId, "requestID", request.ID, "decision", decision)
+	s.publishJoinRequestDecided(channel, request)
+	return joinRequestResponse(request), nil
+}
+
+// publishJoinRequestDecided hands the decision to the notification service.
+// The decision is already stored, so a failed publish is only logged.
+func (s *ChannelManagementService) publishJoinRequestDecided(channel *models.Channel, request *models.JoinRequest) {
+	event := models.JoinRequestDecidedEvent{
+		RequestId:   request.ID,
+		ChannelId:   channel.Id,
+		ChannelName: channel.Name,
+		UserId:      request.UserId,
+		Status:      request.Status,
+		DecidedAt:   request.DecidedAt,
+	}
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("Failed to marshal join request decided event", "error", err.Error())
+		return
+	}
+	if err := s.repo.PublishInJoinRequestDecidedChannel(bytes); err != nil {
+		slog.Error("Failed to publish join request decided event", "requestID", request.ID, "error", err.Error())
 	}
-	return isAdmin, nil
 }
@@ -14,6 +14,7 @@ import (
 	"example.com/channel-management/src/internal/repository"
 	"example.com/channel-management/src/internal/server"
 	"example.com/channel-management/src/internal/service"
+	"example.com/channel-management/src/redis"
 	_ "github.com/lib/pq"
 )
 
@@ -26,8 +27,12 @@ func main() {
 	database.Init(cfg)
 	defer database.Close()
 
+	slog.Info("Initializing redis connection")
+	redis.Init(cfg)
+	defer redis.Close()
+
 	slog.Info("Creating repository")
-	repo := repository.NewChannelRepository(database.DB)
+	repo := repository.NewChannelRepository(database.DB, redis.RedisClient)
 
 	slog.Info("Creating service")
 	service := service.New(*repo)
@@ -0,0 +1,43 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"example.com/channel-management/src/config"
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisClient stays nil while REDIS_HOST is not set. Redis only carries
+// events for other services, so the service runs without it.
+var RedisClient *redis.Client
+
+func Init(cfg *config.Config) {
+	if cfg.Redis.Host == "" {
+		slog.Warn("REDIS_HOST is not set, events are not published")
+		return
+	}
+	redisAddr := fmt.Sprintf("%v:%v", cfg.Redis.Host, cfg.Redis.InnerPort)
+	options := &redis.Options{
+		Password: cfg.Redis.Password,
+		Addr:     redisAddr,
+		DB:       cfg.Redis.Db,
+	}
+	RedisClient = redis.NewClient(options)
+	_, err := RedisClient.Ping(context.Background()).Result()
+	if err != nil {
+		// The client reconnects on its own; events published until then are lost.
+		slog.Error("Redis is not reachable", "addr", redisAddr, "error", err.Error())
+		return
+	}
+	slog.Info("Connected to Redis")
+}
+
+func Close() {
+	if RedisClient == nil {
+		return
+	}
+	slog.Info("Disconneting from Redis")
+	RedisClient.Close()
+}
@@ -15,12 +15,19 @@ type Config struct {
 }
 
 type AppConfig struct {
-	HttpInnerPort int `env:"APP_HTTP_INNER_PORT"`
+	HttpInnerPort int    `env:"APP_HTTP_INNER_PORT"`
+	InstanceId    string `env:"APP_INSTANCE_ID"`
+	// MaxPinsPerRoom bounds the pinned messages of a chat or channel.
+	MaxPinsPerRoom int `env:"APP_MAX_PINS_PER_ROOM" env-default:"50"`
 }
 
 type AuthConfig struct {
 	AuthHost string `env:"AUTH_HOST"`
 	AuthPort string `env:"AUTH_PORT"`
+	// ServiceToken is shared with chat-management and channel-management. It
+	// authorizes the work chat-app does on behalf of users who are not
+	// connected, like sending their scheduled messages.
+	ServiceToken string `env:"AUTH_SERVICE_TOKEN"`
 }
 
 type ChanMgmtConfig struct {
@@ -37,11 +37,62 @@ func Init(cfg *config.Config) {
 		panic(err.Error())
 	}
 
-	db.AutoMigrate(&models.ChatRoomXUser{}, &models.Message{})
+	db.AutoMigrate(&models.ChatRoomXUser{}, &models.Message{}, &models.MessageRevision{}, &models.MessageReceipt{}, &models.MessageReaction{}, &models.PinnedMessage{}, &models.ScheduledMessage{}, &models.Poll{}, &models.PollOption{}, &models.PollVote{})
+	migrateMessageMetadata(db)
+	migrateMessageSearch(db)
+	migrateScheduledMessages(db)
 	DB = db
 	slog.Info("Connected to DB")
 }
 
+// migrateMessageMetadata turns the filePath of messages saved before messages
+// carried several attachments into their only attachment. The file name, type
+// and size of those files were never stored.
+func migrateMessageMetadata(db *gorm.DB) {
+	err := db.Exec(
+		`UPDATE messages SET metadata = CASE
+			WHEN coalesce(metadata->>'filePath', '') = '' OR is_deleted THEN '{}'::jsonb
+			ELSE jsonb_build_object(
+				'mediaStatus', 'complete',
+				'attachments', jsonb_build_array(jsonb_build_object(
+					'fileId', metadata->>'filePath', 'fileName', '', 'contentType', '', 'size', 0
+				))
+			)
+		END
+		WHERE metadata->'filePath' IS NOT NULL`,
+	).Error
+	if err != nil {
+		slog.Error("Error has occured while migrating message metadata", "error", err.Error())
+		panic(err)
+	}
+}
+
+// migrateMessageSearch adds the generated full-text document of message
+// bodies and the index behind message search.
+func migrateMessageSearch(db *gorm.DB) {
+	statements := []string{
+		`ALTER TABLE messages ADD COLUMN IF NOT EXISTS body_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('simple', coalesce(body, ''))) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_messages_body_vector ON messages USING GIN (body_vector)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			slog.Error("Error has occured while migrating message search", "error", err.Error())
+			panic(err)
+		}
+	}
+}
+
+// migrateScheduledMessages drops the sender tokens scheduled messages used
+// to keep.
+func migrateScheduledMessages(db *gorm.DB) {
+	err := db.Exec(`ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS access_token, DROP COLUMN IF EXISTS refresh_token`).Error
+	if err != nil {
+		slog.Error("Error has occured while migrating scheduled messages", "error", err.Error())
+		panic(err)
+	}
+}
+
 func Close() {
 	slog.Info("Disconneting from DB")
 	DB.Close()
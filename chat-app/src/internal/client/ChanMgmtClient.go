@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"example.com/chat-app/src/config"
 	chanMgmt "example.com/chat-app/src/gen/go/channel_mgmt"
@@ -15,6 +16,7 @@ import (
 
 type ChanMgmtGRPCClient struct {
 	chanMgmt.ChannelManagementClient
+	serviceToken string
 }
 
 func NewChanMgmtClient(cfg *config.Config) *ChanMgmtGRPCClient {
@@ -25,17 +27,34 @@ func NewChanMgmtClient(cfg *config.Config) *ChanMgmtGRPCClient {
 	}
 	slog.Info("Connected to ChanManagement")
 	slog.Info(connectionUrl)
-	return &ChanMgmtGRPCClient{chanMgmt.NewChannelManagementClient(conn)}
+	return &ChanMgmtGRPCClient{chanMgmt.NewChannelManagementClient(conn), cfg.Auth.ServiceToken}
 }
 
-func (chanMgmtClient *ChanMgmtGRPCClient) PerformGetChanUsers(channelID, accessToken string, refreshToken string, userId string) ([]uuid.UUID, error) {
+func (chanMgmtClient *ChanMgmtGRPCClient) PerformGetChannel(channelID, accessToken string, refreshToken string, userId string) (*chanMgmt.ChannelResponse, error) {
 	md := metadata.Pairs("authorization", accessToken)
 	md.Append("x-refresh-token", refreshToken)
 	ctx := metadata.NewOutgoingContext(context.Background(), md)
-	resp, err := chanMgmtClient.GetChannel(ctx, &chanMgmt.GetChannelRequest{ChannelId: channelID, UserId: userId})
+	return chanMgmtClient.GetChannel(ctx, &chanMgmt.GetChannelRequest{ChannelId: channelID, UserId: userId})
+}
+
+// PerformGetChannelAsService gets the channel with the service token instead
+// of the tokens of userId.
+func (chanMgmtClient *ChanMgmtGRPCClient) PerformGetChannelAsService(channelID string, userId string) (*chanMgmt.ChannelResponse, error) {
+	md := metadata.Pairs("x-service-token", chanMgmtClient.serviceToken)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	return chanMgmtClient.GetChannel(ctx, &chanMgmt.GetChannelRequest{ChannelId: channelID, UserId: userId})
+}
+
+func (chanMgmtClient *ChanMgmtGRPCClient) PerformGetChanUsers(channelID, accessToken string, refreshToken string, userId string) ([]uuid.UUID, error) {
+	resp, err := chanMgmtClient.PerformGetChannel(channelID, accessToken, refreshToken, userId)
 	if err != nil {
 		return nil, err
 	}
+	return MapChanUsers(resp)
+}
+
+// MapChanUsers returns the participants and admins of a channel.
+func MapChanUsers(resp *chanMgmt.ChannelResponse) ([]uuid.UUID, error) {
 	var userIds []uuid.UUID
 	for _, id := range resp.ParticipantsIds {
 		userId, err := uuid.Parse(id)
@@ -54,13 +73,43 @@ func (chanMgmtClient *ChanMgmtGRPCClient) PerformGetChanUsers(channelID, accessT
 	return userIds, nil
 }
 
-func (chanMgmtClient *ChanMgmtGRPCClient) PerformIsAdmin(channelID, accessToken string, refreshToken string, userId string) (bool, error) {
+// HasChannelPermission reports whether the role of userId in the channel
+// grants permission. Users outside the channel have no permissions.
+func HasChannelPermission(resp *chanMgmt.ChannelResponse, userId string, permission string) bool {
+	for _, member := range resp.Members {
+		if member.UserId == userId {
+			return slices.Contains(member.Permissions, permission)
+		}
+	}
+	return false
+}
+
+func (chanMgmtClient *ChanMgmtGRPCClient) PerformCheckPermission(channelID, accessToken string, refreshToken string, userId string, permission string) (bool, error) {
 	md := metadata.Pairs("authorization", accessToken)
 	md.Append("x-refresh-token", refreshToken)
 	ctx := metadata.NewOutgoingContext(context.Background(), md)
-	resp, err := chanMgmtClient.IsChannelAdmin(ctx, &chanMgmt.IsAdminRequest{ChannelId: channelID, UserId: userId})
+	resp, err := chanMgmtClient.CheckChannelPermission(ctx, &chanMgmt.CheckPermissionRequest{ChannelId: channelID, UserId: userId, Permission: permission})
 	if err != nil {
 		return false, err
 	}
-	return resp.IsAdmin, nil
+	return resp.Allowed, nil
+}
+
+func (chanMgmtClient *ChanMgmtGRPCClient) PerformIsParticipant(channelID, accessToken string, refreshToken string, userId string) (bool, error) {
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return false, err
+	}
+	channelUsers, err := chanMgmtClient.PerformGetChanUsers(channelID, accessToken, refreshToken, userId)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(channelUsers, userUUID), nil
+}
+
+func (chanMgmtClient *ChanMgmtGRPCClient) PerformListUserChannels(accessToken string, refreshToken string, userId string, limit int32, pageToken string) (*chanMgmt.ListUserChannelsResponse, error) {
+	md := metadata.Pairs("authorization", accessToken)
+	md.Append("x-refresh-token", refreshToken)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	return chanMgmtClient.ListUserChannels(ctx, &chanMgmt.ListUserChannelsRequest{UserId: userId, Limit: limit, PageToken: pageToken})
 }
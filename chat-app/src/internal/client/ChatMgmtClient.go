@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"example.com/chat-app/src/config"
 	chatMgmt "example.com/chat-app/src/gen/go/chat_mgmt"
@@ -15,6 +16,7 @@ import (
 
 type ChatMgmtGRPCClient struct {
 	chatMgmt.ChatManagementClient
+	serviceToken string
 }
 
 func NewChatMgmtClient(cfg *config.Config) *ChatMgmtGRPCClient {
@@ -25,17 +27,34 @@ func NewChatMgmtClient(cfg *config.Config) *ChatMgmtGRPCClient {
 	}
 	slog.Info("Connected to ChatManagement")
 	slog.Info(connectionUrl)
-	return &ChatMgmtGRPCClient{chatMgmt.NewChatManagementClient(conn)}
+	return &ChatMgmtGRPCClient{chatMgmt.NewChatManagementClient(conn), cfg.Auth.ServiceToken}
 }
 
-func (chatMgmtClient *ChatMgmtGRPCClient) PerformGetChatUsers(chatID, accessToken string, refreshToken string, userId string) ([]uuid.UUID, error) {
+func (chatMgmtClient *ChatMgmtGRPCClient) PerformGetChat(chatID, accessToken string, refreshToken string, userId string) (*chatMgmt.ChatRoomResponse, error) {
 	md := metadata.Pairs("authorization", accessToken)
 	md.Append("x-refresh-token", refreshToken)
 	ctx := metadata.NewOutgoingContext(context.Background(), md)
-	resp, err := chatMgmtClient.GetChat(ctx, &chatMgmt.GetChatRequest{ChatId: chatID, UserId: userId})
+	return chatMgmtClient.GetChat(ctx, &chatMgmt.GetChatRequest{ChatId: chatID, UserId: userId})
+}
+
+// PerformGetChatAsService gets the chat with the service token instead of
+// the tokens of userId.
+func (chatMgmtClient *ChatMgmtGRPCClient) PerformGetChatAsService(chatID string, userId string) (*chatMgmt.ChatRoomResponse, error) {
+	md := metadata.Pairs("x-service-token", chatMgmtClient.serviceToken)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	return chatMgmtClient.GetChat(ctx, &chatMgmt.GetChatRequest{ChatId: chatID, UserId: userId})
+}
+
+func (chatMgmtClient *ChatMgmtGRPCClient) PerformGetChatUsers(chatID, accessToken string, refreshToken string, userId string) ([]uuid.UUID, error) {
+	resp, err := chatMgmtClient.PerformGetChat(chatID, accessToken, refreshToken, userId)
 	if err != nil {
 		return nil, err
 	}
+	return MapChatUsers(resp)
+}
+
+// MapChatUsers returns the participants and admins of a chat.
+func MapChatUsers(resp *chatMgmt.ChatRoomResponse) ([]uuid.UUID, error) {
 	var userIds []uuid.UUID
 	for _, id := range resp.ParticipantsIds {
 		userId, err := uuid.Parse(id)
@@ -53,3 +72,44 @@ func (chatMgmtClient *ChatMgmtGRPCClient) PerformGetChatUsers(chatID, accessToke
 	}
 	return userIds, nil
 }
+
+func (chatMgmtClient *ChatMgmtGRPCClient) PerformIsParticipant(chatID, accessToken string, refreshToken string, userId string) (bool, error) {
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return false, err
+	}
+	chatUsers, err := chatMgmtClient.PerformGetChatUsers(chatID, accessToken, refreshToken, userId)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(chatUsers, userUUID), nil
+}
+
+// HasChatPermission reports whether the role of userId in the chat grants
+// permission. Users outside the chat have no permissions.
+func HasChatPermission(resp *chatMgmt.ChatRoomResponse, userId string, permission string) bool {
+	for _, member := range resp.Members {
+		if member.UserId == userId {
+			return slices.Contains(member.Permissions, permission)
+		}
+	}
+	return false
+}
+
+func (chatMgmtClient *ChatMgmtGRPCClient) PerformCheckPermission(chatID, accessToken string, refreshToken string, userId string, permission string) (bool, error) {
+	md := metadata.Pairs("authorization", accessToken)
+	md.Append("x-refresh-token", refreshToken)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	resp, err := chatMgmtClient.CheckChatPermission(ctx, &chatMgmt.CheckPermissionRequest{ChatId: chatID, UserId: userId, Permission: permission})
+	if err != nil {
+		return false, err
+	}
+	return resp.Allowed, nil
+}
+
+func (chatMgmtClient *ChatMgmtGRPCClient) PerformListUserChats(accessToken string, refreshToken string, userId string, limit int32, pageToken string) (*chatMgmt.ListUserChatsResponse, error) {
+	md := metadata.Pairs("authorization", accessToken)
+	md.Append("x-refresh-token", refreshToken)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	return chatMgmtClient.ListUserChats(ctx, &chatMgmt.ListUserChatsRequest{UserId: userId, Limit: limit, PageToken: pageToken})
+}
@@ -1,62 +1,627 @@
 package controller
 
 import (
-	"context"
 	"encoding/json"
 	e "errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"example.com/chat-app/src/gen/go/auth"
+	chanMgmt "example.com/chat-app/src/gen/go/channel_mgmt"
+	chatMgmt "example.com/chat-app/src/gen/go/chat_mgmt"
 	"example.com/chat-app/src/internal/client"
 	"example.com/chat-app/src/internal/dto"
 	"example.com/chat-app/src/internal/errors"
 	"example.com/chat-app/src/internal/models"
 	"example.com/chat-app/src/internal/service"
-	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MessageHistoryController struct {
 	messageHistoryService *service.MessageHistoryService
+	authClient            *client.AuthGRPCClient
+	channelMgmtClient     *client.ChanMgmtGRPCClient
+	chatMgmtClient        *client.ChatMgmtGRPCClient
 }
 
-func NewMessageHistoryController(messageHistoryService *service.MessageHistoryService) *MessageHistoryController {
+func NewMessageHistoryController(messageHistoryService *service.MessageHistoryService, authClient *client.AuthGRPCClient, channelMgmtClient *client.ChanMgmtGRPCClient, chatMgmtClient *client.ChatMgmtGRPCClient) *MessageHistoryController {
 	return &MessageHistoryController{
 		messageHistoryService: messageHistoryService,
+		authClient:            authClient,
+		channelMgmtClient:     channelMgmtClient,
+		chatMgmtClient:        chatMgmtClient,
 	}
 }
 
+// authorizeRoom parses the room id of the request, checks its credentials
+// and makes sure the caller belongs to the room. It writes the error response
+// itself and returns nil on failure.
+func (m *MessageHistoryController) authorizeRoom(w http.ResponseWriter, r *http.Request, rawChatRoomId string) (uuid.UUID, *auth.AuthorizeResponse) {
+	chatRoomId, err := uuid.Parse(rawChatRoomId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return uuid.Nil, nil
+	}
+	authResp, _ := authorizeRequest(m.authClient, w, r)
+	if authResp == nil || !m.checkParticipant(w, authResp, chatRoomId) {
+		return uuid.Nil, nil
+	}
+	return chatRoomId, authResp
+}
+
+// checkParticipant makes sure the caller belongs to chatRoomId. It writes the
+// error response itself and returns false otherwise.
+func (m *MessageHistoryController) checkParticipant(w http.ResponseWriter, authResp *auth.AuthorizeResponse, chatRoomId uuid.UUID) bool {
+	isParticipant, err := isRoomParticipant(m.chatMgmtClient, m.channelMgmtClient, chatRoomId.String(), authResp.AccessToken, authResp.RefreshToken, authResp.UserId)
+	if err != nil {
+		slog.Error("Failed to check room participants", "error", err.Error())
+		if e.Is(err, errors.ErrRoomNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", authResp.UserId, chatRoomId))
+		http.Error(w, "permission denied", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// authorizeRequest checks the credentials of the request and refreshes the
+// token cookies. It writes the error response itself and returns nil on
+// failure.
+func authorizeRequest(authClient *client.AuthGRPCClient, w http.ResponseWriter, r *http.Request) (*auth.AuthorizeResponse, uuid.UUID) {
+	accessToken, refreshToken, userIdH, err := extractTokens(r)
+	if err != nil {
+		slog.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, uuid.Nil
+	}
+	authResp, err := authClient.PerformAuthorize(r.Context(), accessToken, refreshToken, userIdH)
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, uuid.Nil
+	}
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	userId, err := uuid.Parse(authResp.UserId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, uuid.Nil
+	}
+	return authResp, userId
+}
+
 func (m *MessageHistoryController) GetHistoryHandler(w http.ResponseWriter, r *http.Request) {
-	chatRoomId, err := uuid.Parse(strings.Split(r.URL.Path, "/")[1])
+	chatRoomId, authResp := m.authorizeRoom(w, r, strings.Split(r.URL.Path, "/")[1])
+	if authResp == nil {
+		return
+	}
+	query := r.URL.Query()
+	historyReq := &dto.HistoryRequest{
+		Before: query.Get("before"),
+		After:  query.Get("after"),
+	}
+	var err error
+	if limit := query.Get("limit"); limit != "" {
+		historyReq.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, err := m.messageHistoryService.GetHistory(chatRoomId, historyReq)
+	if err != nil {
+		if e.Is(err, errors.ErrInvalidCursor) || e.Is(err, errors.ErrInvalidLimit) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	historyResp := dto.HistoryResponse{
+		Messages:   make([]dto.MessageResponse, 0, len(page.Messages)),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	for _, message := range page.Messages {
+		historyResp.Messages = append(historyResp.Messages, *models.MapMessageToResponse(&message))
+	}
+
+	response, err := json.Marshal(historyResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// GetThreadHandler lists the replies under a channel message. It accepts the
+// same before, after and limit parameters as the history.
+func (m *MessageHistoryController) GetThreadHandler(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	rootId, err := uuid.Parse(pathParts[3])
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	messages, err := m.messageHistoryService.GetHistory(chatRoomId)
+	chatRoomId, authResp := m.authorizeRoom(w, r, pathParts[1])
+	if authResp == nil {
+		return
+	}
+	query := r.URL.Query()
+	historyReq := &dto.HistoryRequest{
+		Before: query.Get("before"),
+		After:  query.Get("after"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		historyReq.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, err := m.messageHistoryService.GetThread(chatRoomId, rootId, historyReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		if e.Is(err, errors.ErrMessageNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if e.Is(err, errors.ErrInvalidCursor) || e.Is(err, errors.ErrInvalidLimit) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	var messageResps []dto.MessageResponse
+	threadResp := dto.ThreadResponse{
+		Root:       *models.MapMessageToResponse(&page.Root),
+		Replies:    make([]dto.MessageResponse, 0, len(page.Messages)),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	for _, message := range page.Messages {
+		threadResp.Replies = append(threadResp.Replies, *models.MapMessageToResponse(&message))
+	}
 
+	response, err := json.Marshal(threadResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// GetPinsHandler lists the pinned messages of a room, latest pin first.
+func (m *MessageHistoryController) GetPinsHandler(w http.ResponseWriter, r *http.Request) {
+	chatRoomId, authResp := m.authorizeRoom(w, r, strings.Split(r.URL.Path, "/")[1])
+	if authResp == nil {
+		return
+	}
+
+	messages, err := m.messageHistoryService.GetPinnedMessages(chatRoomId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	pinsResp := dto.PinsResponse{Messages: make([]dto.MessageResponse, 0, len(messages))}
 	for _, message := range messages {
-		messageResps = append(messageResps, *models.MapMessageToResponse(&message))
+		pinsResp.Messages = append(pinsResp.Messages, *models.MapMessageToResponse(&message))
+	}
+
+	response, err := json.Marshal(pinsResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// GetUnreadCountsHandler reports unread message counts of the caller for every
+// room passed in the repeated chatRoomId query parameter.
+func (m *MessageHistoryController) GetUnreadCountsHandler(w http.ResponseWriter, r *http.Request) {
+	rawIds := r.URL.Query()["chatRoomId"]
+	if len(rawIds) == 0 {
+		http.Error(w, "chatRoomId query parameter is required", http.StatusBadRequest)
+		return
+	}
+	chatRoomIds := make([]uuid.UUID, 0, len(rawIds))
+	for _, rawId := range rawIds {
+		chatRoomId, err := uuid.Parse(rawId)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		chatRoomIds = append(chatRoomIds, chatRoomId)
+	}
+
+	authResp, userId := authorizeRequest(m.authClient, w, r)
+	if authResp == nil {
+		return
+	}
+
+	for _, chatRoomId := range chatRoomIds {
+		if !m.checkParticipant(w, authResp, chatRoomId) {
+			return
+		}
+	}
+
+	counts, err := m.messageHistoryService.GetUnreadCounts(userId, chatRoomIds)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	unreadResp := dto.UnreadCountsResponse{ChatRooms: make([]dto.UnreadCountResponse, 0, len(counts))}
+	for _, count := range counts {
+		unreadResp.ChatRooms = append(unreadResp.ChatRooms, dto.UnreadCountResponse{
+			ChatRoomId:  count.ChatRoomId.String(),
+			UnreadCount: count.Count,
+		})
+	}
+
+	response, err := json.Marshal(unreadResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// GetInboxHandler lists the chats and channels of the caller with their
+// latest message and unread count. Chats and channels are paged
+// independently through chatsPageToken and channelsPageToken.
+func (m *MessageHistoryController) GetInboxHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var limit int64
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		var err error
+		limit, err = strconv.ParseInt(rawLimit, 10, 32)
+		if err != nil || limit < 0 {
+			http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	authResp, userId := authorizeRequest(m.authClient, w, r)
+	if authResp == nil {
+		return
+	}
+
+	chats, err := m.chatMgmtClient.PerformListUserChats(authResp.AccessToken, authResp.RefreshToken, authResp.UserId, int32(limit), query.Get("chatsPageToken"))
+	if err != nil {
+		slog.Error("Failed to list user chats", "error", err.Error())
+		http.Error(w, err.Error(), listErrorStatus(err))
+		return
+	}
+	channels, err := m.channelMgmtClient.PerformListUserChannels(authResp.AccessToken, authResp.RefreshToken, authResp.UserId, int32(limit), query.Get("channelsPageToken"))
+	if err != nil {
+		slog.Error("Failed to list user channels", "error", err.Error())
+		http.Error(w, err.Error(), listErrorStatus(err))
+		return
+	}
+
+	conversations := make([]models.Conversation, 0, len(chats.Chats)+len(channels.Channels))
+	for _, chat := range chats.Chats {
+		chatId, err := uuid.Parse(chat.ChatId)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		conversations = append(conversations, models.Conversation{
+			ChatRoomId:      chatId,
+			RoomType:        models.ChatRoomType,
+			Name:            chat.Name,
+			Description:     chat.Description,
+			IsDirect:        chat.IsDirect,
+			ParticipantsIds: chat.ParticipantsIds,
+		})
+	}
+	for _, channel := range channels.Channels {
+		channelId, err := uuid.Parse(channel.ChannelId)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		conversations = append(conversations, models.Conversation{
+			ChatRoomId:  channelId,
+			RoomType:    models.ChannelRoomType,
+			Name:        channel.Name,
+			Description: channel.Description,
+		})
+	}
+
+	conversations, err = m.messageHistoryService.GetInbox(userId, conversations)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	inboxResp := dto.InboxResponse{
+		Conversations:         make([]dto.ConversationResponse, 0, len(conversations)),
+		ChatsNextPageToken:    chats.NextPageToken,
+		ChannelsNextPageToken: channels.NextPageToken,
+	}
+	for _, conversation := range conversations {
+		inboxResp.Conversations = append(inboxResp.Conversations, models.MapConversationToResponse(&conversation))
+	}
+
+	response, err := json.Marshal(inboxResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// SearchMessagesHandler runs a full-text search over the messages of every
+// chat and channel the caller belongs to, or of the single room passed in
+// chatRoomId. Results are paginated with the returned cursor.
+func (m *MessageHistoryController) SearchMessagesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	searchReq := &dto.SearchRequest{
+		Query:      query.Get("q"),
+		ChatRoomId: query.Get("chatRoomId"),
+		SenderId:   query.Get("senderId"),
+		Cursor:     query.Get("cursor"),
+	}
+	var err error
+	if from := query.Get("from"); from != "" {
+		searchReq.From, err = strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		searchReq.To, err = strconv.ParseUint(to, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if hasAttachment := query.Get("hasAttachment"); hasAttachment != "" {
+		searchReq.HasAttachment, err = strconv.ParseBool(hasAttachment)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		searchReq.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	authResp, _ := authorizeRequest(m.authClient, w, r)
+	if authResp == nil {
+		return
+	}
+
+	var chatRoomIds []uuid.UUID
+	if searchReq.ChatRoomId != "" {
+		chatRoomId, err := uuid.Parse(searchReq.ChatRoomId)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !m.checkParticipant(w, authResp, chatRoomId) {
+			return
+		}
+		chatRoomIds = []uuid.UUID{chatRoomId}
+	} else {
+		chatRoomIds, err = listUserRoomIds(m.chatMgmtClient, m.channelMgmtClient, authResp.AccessToken, authResp.RefreshToken, authResp.UserId)
+		if err != nil {
+			slog.Error("Failed to list user rooms", "error", err.Error())
+			http.Error(w, err.Error(), listErrorStatus(err))
+			return
+		}
+	}
+
+	page, err := m.messageHistoryService.SearchMessages(chatRoomIds, searchReq)
+	if err != nil {
+		if e.Is(err, errors.ErrInvalidSearchQuery) || e.Is(err, errors.ErrInvalidCursor) || e.Is(err, errors.ErrInvalidLimit) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	searchResp := dto.SearchResponse{
+		Results:    make([]dto.SearchResultResponse, 0, len(page.Hits)),
+		NextCursor: page.NextCursor,
+	}
+	for _, hit := range page.Hits {
+		searchResp.Results = append(searchResp.Results, models.MapSearchHitToResponse(&hit))
+	}
+
+	response, err := json.Marshal(searchResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// ScheduledMessageController lets users queue messages for later delivery
+// and manage the ones not sent yet.
+type ScheduledMessageController struct {
+	messageService    *service.MessageService
+	authClient        *client.AuthGRPCClient
+	channelMgmtClient *client.ChanMgmtGRPCClient
+	chatMgmtClient    *client.ChatMgmtGRPCClient
+}
+
+func NewScheduledMessageController(messageService *service.MessageService, authClient *client.AuthGRPCClient, channelMgmtClient *client.ChanMgmtGRPCClient, chatMgmtClient *client.ChatMgmtGRPCClient) *ScheduledMessageController {
+	return &ScheduledMessageController{
+		messageService:    messageService,
+		authClient:        authClient,
+		channelMgmtClient: channelMgmtClient,
+		chatMgmtClient:    chatMgmtClient,
+	}
+}
+
+func (s *ScheduledMessageController) authorize(w http.ResponseWriter, r *http.Request) (*auth.AuthorizeResponse, uuid.UUID) {
+	return authorizeRequest(s.authClient, w, r)
+}
+
+func (s *ScheduledMessageController) ScheduleMessageHandler(w http.ResponseWriter, r *http.Request) {
+	scheduleReq := &dto.ScheduleMessageRequest{}
+	err := json.NewDecoder(r.Body).Decode(scheduleReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	authResp, userId := s.authorize(w, r)
+	if authResp == nil {
+		return
 	}
 
-	response, err := json.Marshal(messageResps)
+	roomType, isParticipant, err := roomParticipation(s.chatMgmtClient, s.channelMgmtClient, scheduleReq.ChatRoomId, authResp.AccessToken, authResp.RefreshToken, authResp.UserId)
 	if err != nil {
+		slog.Error("Failed to check room participants", "error", err.Error())
+		if e.Is(err, errors.ErrRoomNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", authResp.UserId, scheduleReq.ChatRoomId))
+		http.Error(w, "permission denied", http.StatusForbidden)
+		return
+	}
+
+	scheduled, err := s.messageService.ScheduleMessage(userId, roomType, scheduleReq, authResp.AccessToken, authResp.RefreshToken)
+	if err != nil {
+		http.Error(w, err.Error(), messageErrorStatus(err))
+		return
 	}
+	response, err := json.Marshal(models.MapScheduledMessageToResponse(scheduled))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
 	w.Write(response)
 }
 
+// ListScheduledMessagesHandler lists the pending messages of the caller,
+// limited to one room when chatRoomId is given.
+func (s *ScheduledMessageController) ListScheduledMessagesHandler(w http.ResponseWriter, r *http.Request) {
+	var chatRoomId *uuid.UUID
+	if rawId := r.URL.Query().Get("chatRoomId"); rawId != "" {
+		parsedId, err := uuid.Parse(rawId)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		chatRoomId = &parsedId
+	}
+	authResp, userId := s.authorize(w, r)
+	if authResp == nil {
+		return
+	}
+
+	scheduled, err := s.messageService.ListScheduledMessages(userId, chatRoomId)
+	if err != nil {
+		http.Error(w, err.Error(), messageErrorStatus(err))
+		return
+	}
+	scheduledResp := dto.ScheduledMessagesResponse{Messages: make([]dto.ScheduledMessageResponse, 0, len(scheduled))}
+	for _, message := range scheduled {
+		scheduledResp.Messages = append(scheduledResp.Messages, models.MapScheduledMessageToResponse(&message))
+	}
+	response, err := json.Marshal(scheduledResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+func (s *ScheduledMessageController) EditScheduledMessageHandler(w http.ResponseWriter, r *http.Request) {
+	scheduledId, err := uuid.Parse(r.PathValue("scheduledMessageId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	editReq := &dto.EditScheduledMessageRequest{}
+	err = json.NewDecoder(r.Body).Decode(editReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	authResp, userId := s.authorize(w, r)
+	if authResp == nil {
+		return
+	}
+
+	scheduled, err := s.messageService.EditScheduledMessage(userId, scheduledId, editReq)
+	if err != nil {
+		http.Error(w, err.Error(), messageErrorStatus(err))
+		return
+	}
+	response, err := json.Marshal(models.MapScheduledMessageToResponse(scheduled))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+func (s *ScheduledMessageController) CancelScheduledMessageHandler(w http.ResponseWriter, r *http.Request) {
+	scheduledId, err := uuid.Parse(r.PathValue("scheduledMessageId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	authResp, userId := s.authorize(w, r)
+	if authResp == nil {
+		return
+	}
+
+	err = s.messageService.CancelScheduledMessage(userId, scheduledId)
+	if err != nil {
+		http.Error(w, err.Error(), messageErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *ScheduledMessageController) StartSendingScheduledMessages() {
+	s.messageService.SendScheduledMessages()
+}
+
 type WebsocketController struct {
 	messageService    *service.MessageService
-	broadcastChannel  chan *models.Message
 	authClient        *client.AuthGRPCClient
 	channelMgmtClient *client.ChanMgmtGRPCClient
 	chatMgmtClient    *client.ChatMgmtGRPCClient
@@ -65,7 +630,6 @@ type WebsocketController struct {
 func NewWebsocketController(messageService *service.MessageService, authClient *client.AuthGRPCClient, channelMgmtClient *client.ChanMgmtGRPCClient, chatMgmtClient *client.ChatMgmtGRPCClient) *WebsocketController {
 	return &WebsocketController{
 		messageService:    messageService,
-		broadcastChannel:  make(chan *models.Message),
 		authClient:        authClient,
 		channelMgmtClient: channelMgmtClient,
 		chatMgmtClient:    chatMgmtClient,
@@ -73,21 +637,10 @@ func NewWebsocketController(messageService *service.MessageService, authClient *
 }
 
 func (ws *WebsocketController) extractUserIdAndTokens(wsConnection *websocket.Conn, r *http.Request) (uuid.UUID, string, string) {
-	header := r.Header.Get("Authorization")
-	accessToken := strings.Split(header, "Bearer ")[1]
-
-	cookie, err := r.Cookie("X-Refresh-Token")
+	accessToken, refreshToken, userIdH, err := extractTokens(r)
 	if err != nil {
-		slog.Error("X-Refresh-Token cookie not found")
-		wsConnection.WriteJSON(models.ErrorMessageResponse{Error: "X-Refresh-Token cookie not found"})
-		return uuid.Nil, "", ""
-	}
-	refreshToken := cookie.Value
-
-	userIdH := r.Header.Get("X-User-Id")
-	if userIdH == "" {
-		slog.Error("X-User-Id header not found")
-		wsConnection.WriteJSON(models.ErrorMessageResponse{Error: "X-User-Id header not found"})
+		slog.Error(err.Error())
+		wsConnection.WriteJSON(models.ErrorMessageResponse{Error: err.Error()})
 		return uuid.Nil, "", ""
 	}
 
@@ -119,6 +672,9 @@ func (ws *WebsocketController) SendMessageInChatRoomHandler(w http.ResponseWrite
 	slog.Debug("Connected to websocket server")
 	defer wsConnection.Close()
 	userId, accessToken, refreshToken := ws.extractUserIdAndTokens(wsConnection, r)
+	if userId == uuid.Nil {
+		return
+	}
 
 	err = ws.messageService.ReadMessagesFromChatRoom(userId, wsConnection, accessToken, refreshToken)
 	if err != nil {
@@ -143,6 +699,9 @@ func (ws *WebsocketController) SendMessageInChannelHandler(w http.ResponseWriter
 	slog.Debug("Connected to websocket server")
 	defer wsConnection.Close()
 	userId, accessToken, refreshToken := ws.extractUserIdAndTokens(wsConnection, r)
+	if userId == uuid.Nil {
+		return
+	}
 
 	err = ws.messageService.ReadMessagesFromChannel(userId, wsConnection, accessToken, refreshToken)
 	if err != nil {
@@ -161,16 +720,44 @@ func (ws *WebsocketController) StartListeningFileChannel() {
 	ws.messageService.ListenFileChannel()
 }
 
-func (ws *WebsocketController) StartBroadcastingToChatRooms() {
-	subscriber := ws.messageService.SubscribeToMessageChannel(ws.messageService.RedisChannelForChatRoomMessagesName)
-	err := subscriber.Ping(context.Background())
-	if err != nil {
-		slog.Error("Channel Not Available", "channel", ws.messageService.RedisChannelForChatRoomMessagesName)
-		return
+func (ws *WebsocketController) StartListeningChatDeletedChannel() {
+	ws.messageService.ListenChatDeletedChannel()
+}
+
+func (ws *WebsocketController) StartSweepingPendingMedia() {
+	ws.messageService.SweepPendingMedia()
+}
+
+func (ws *WebsocketController) StartReapingExpiredMessages() {
+	ws.messageService.ReapExpiredMessages()
+}
+
+func (ws *WebsocketController) StartListeningInstanceChannel() {
+	ws.messageService.ListenInstanceChannel()
+}
+
+// getChat reads the chat of a queued event with the tokens of its actor.
+// Events the server raised on its own, like scheduled sends, carry no
+// tokens and are read with the service token.
+func (ws *WebsocketController) getChat(chatRoomId string, accessToken string, refreshToken string, actorId string) (*chatMgmt.ChatRoomResponse, error) {
+	if accessToken == "" {
+		return ws.chatMgmtClient.PerformGetChatAsService(chatRoomId, actorId)
+	}
+	return ws.chatMgmtClient.PerformGetChat(chatRoomId, accessToken, refreshToken, actorId)
+}
+
+func (ws *WebsocketController) getChannel(channelId string, accessToken string, refreshToken string, actorId string) (*chanMgmt.ChannelResponse, error) {
+	if accessToken == "" {
+		return ws.channelMgmtClient.PerformGetChannelAsService(channelId, actorId)
 	}
-	slog.Info("Channel Available", "channel", ws.messageService.RedisChannelForChatRoomMessagesName)
+	return ws.channelMgmtClient.PerformGetChannel(channelId, accessToken, refreshToken, actorId)
+}
+
+func (ws *WebsocketController) StartBroadcastingToChatRooms() {
+	queueName := ws.messageService.RedisQueueForChatRoomMessagesName
+	slog.Info("Consuming queue", "queue", queueName)
 	for {
-		messageWithTokens, err := receiveMessageFromRedis(subscriber)
+		messageWithTokens, err := ws.receiveMessageFromQueue(queueName)
 		if err != nil {
 			slog.Error(err.Error())
 			continue
@@ -182,13 +769,39 @@ func (ws *WebsocketController) StartBroadcastingToChatRooms() {
 		accessToken := messageWithTokens.AccessToken
 		refreshToken := messageWithTokens.RefreshToken
 
+		actorId := messageWithTokens.ActorId
+		if actorId == uuid.Nil {
+			actorId = message.SenderId
+		}
+
 		chatRoomId := message.ChatRoomId.String()
-		chatUsers, err := ws.chatMgmtClient.PerformGetChatUsers(chatRoomId, accessToken, refreshToken, message.SenderId.String())
+		chat, err := ws.getChat(chatRoomId, accessToken, refreshToken, actorId.String())
 		if err != nil {
 			slog.Error(err.Error())
 			continue
 		}
+		chatUsers, err := client.MapChatUsers(chat)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		// Expiry events are raised by the server and announced even after
+		// the sender left the chat.
+		isExpiry := accessToken == "" && messageWithTokens.Type == dto.MessageTypeDelete
+		if !isExpiry && !slices.Contains(chatUsers, actorId) {
+			slog.Error("User is not a participant of the chat", "chat", chatRoomId, "user", actorId)
+			continue
+		}
+		if messageWithTokens.Type == dto.MessageTypeCreate && !client.HasChatPermission(chat, actorId.String(), models.PermissionPost) {
+			slog.Error("User is not allowed to post in the chat", "chat", chatRoomId, "user", actorId)
+			continue
+		}
+		if chat.IsDirect && len(chatUsers) != 2 {
+			slog.Error("Direct chat must have exactly two participants", "chat", chatRoomId, "participants", len(chatUsers))
+			continue
+		}
 		readyMessage := models.ReadyMessage{
+			Type:         messageWithTokens.Type,
 			Message:      message,
 			AccessToken:  accessToken,
 			RefreshToken: refreshToken,
@@ -199,15 +812,10 @@ func (ws *WebsocketController) StartBroadcastingToChatRooms() {
 }
 
 func (ws *WebsocketController) StartBroadcastingToChannels() {
-	subscriber := ws.messageService.SubscribeToMessageChannel(ws.messageService.RedisChannelForChannelMessagesName)
-	err := subscriber.Ping(context.Background())
-	if err != nil {
-		slog.Error("Channel Not Available", "channel", ws.messageService.RedisChannelForChannelMessagesName)
-		return
-	}
-	slog.Info("Channel Available", "channel", ws.messageService.RedisChannelForChannelMessagesName)
+	queueName := ws.messageService.RedisQueueForChannelMessagesName
+	slog.Info("Consuming queue", "queue", queueName)
 	for {
-		messageWithTokens, err := receiveMessageFromRedis(subscriber)
+		messageWithTokens, err := ws.receiveMessageFromQueue(queueName)
 		if err != nil {
 			slog.Error(err.Error())
 			continue
@@ -219,18 +827,32 @@ func (ws *WebsocketController) StartBroadcastingToChannels() {
 		accessToken := messageWithTokens.AccessToken
 		refreshToken := messageWithTokens.RefreshToken
 
+		actorId := messageWithTokens.ActorId
+		if actorId == uuid.Nil {
+			actorId = message.SenderId
+		}
+
 		channelId := message.ChatRoomId.String()
-		isAdmin, err := ws.channelMgmtClient.PerformIsAdmin(channelId, accessToken, refreshToken, message.SenderId.String())
-		if err != nil || !isAdmin {
+		channel, err := ws.getChannel(channelId, accessToken, refreshToken, actorId.String())
+		if err != nil {
 			slog.Error(err.Error())
 			continue
 		}
-		channelUsers, err := ws.channelMgmtClient.PerformGetChanUsers(channelId, accessToken, refreshToken, message.SenderId.String())
+		channelUsers, err := client.MapChanUsers(channel)
 		if err != nil {
 			slog.Error(err.Error())
 			continue
 		}
+		if !slices.Contains(channelUsers, actorId) {
+			slog.Error("User is not a participant of the channel", "channel", channelId, "user", actorId)
+			continue
+		}
+		if messageWithTokens.Type == dto.MessageTypeCreate && !client.HasChannelPermission(channel, actorId.String(), models.PermissionPost) {
+			slog.Error("User is not allowed to post in the channel", "channel", channelId, "user", actorId)
+			continue
+		}
 		readyMessage := models.ReadyMessage{
+			Type:         messageWithTokens.Type,
 			Message:      message,
 			AccessToken:  accessToken,
 			RefreshToken: refreshToken,
@@ -240,15 +862,183 @@ func (ws *WebsocketController) StartBroadcastingToChannels() {
 	}
 }
 
-func receiveMessageFromRedis(subscriber *redis.PubSub) (*models.MessageWithTokens, error) {
-	messageWithTokens := &models.MessageWithTokens{}
+// StartBroadcastingEphemeralEvents fans typing and presence events out to the
+// participants of the room. Events from users outside the room are dropped.
+func (ws *WebsocketController) StartBroadcastingEphemeralEvents() {
+	queueName := ws.messageService.RedisQueueForEphemeralName
+	slog.Info("Consuming queue", "queue", queueName)
+	for {
+		payload, err := ws.messageService.PopFromMessageQueue(queueName)
+		if err != nil {
+			slog.Error(err.Error())
+			time.Sleep(queueRetryInterval)
+			continue
+		}
+		event := &models.EphemeralEvent{}
+		err = json.Unmarshal([]byte(payload), event)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+
+		roomId := event.ChatRoomId.String()
+		var participants []uuid.UUID
+		if event.RoomType == models.ChannelRoomType {
+			participants, err = ws.channelMgmtClient.PerformGetChanUsers(roomId, event.AccessToken, event.RefreshToken, event.UserId.String())
+		} else {
+			participants, err = ws.chatMgmtClient.PerformGetChatUsers(roomId, event.AccessToken, event.RefreshToken, event.UserId.String())
+		}
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		if !slices.Contains(participants, event.UserId) {
+			slog.Error("User is not a participant of the room", "room", roomId, "user", event.UserId, "event", event.Type)
+			continue
+		}
+		ws.messageService.BroadcastEphemeral(participants, event)
+	}
+}
+
+// queueRetryInterval throttles the consumers while Redis is unavailable.
+const queueRetryInterval = time.Second
+
+func (ws *WebsocketController) receiveMessageFromQueue(queueName string) (*models.MessageWithTokens, error) {
 	slog.Debug("Waiting for message")
-	channel := subscriber.Channel()
-	receivedMessage := <-channel
-	slog.Debug(receivedMessage.Payload)
-	err := json.Unmarshal([]byte(receivedMessage.Payload), messageWithTokens)
+	payload, err := ws.messageService.PopFromMessageQueue(queueName)
+	if err != nil {
+		time.Sleep(queueRetryInterval)
+		return nil, err
+	}
+	slog.Debug(payload)
+	messageWithTokens := &models.MessageWithTokens{}
+	err = json.Unmarshal([]byte(payload), messageWithTokens)
 	if err != nil {
 		return nil, err
 	}
 	return messageWithTokens, nil
 }
+
+func extractTokens(r *http.Request) (string, string, string, error) {
+	accessToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || accessToken == "" {
+		return "", "", "", fmt.Errorf("%w: Authorization header not found", errors.ErrMissingCredentials)
+	}
+
+	cookie, err := r.Cookie("X-Refresh-Token")
+	if err != nil {
+		return "", "", "", fmt.Errorf("%w: X-Refresh-Token cookie not found", errors.ErrMissingCredentials)
+	}
+
+	userId := r.Header.Get("X-User-Id")
+	if userId == "" {
+		return "", "", "", fmt.Errorf("%w: X-User-Id header not found", errors.ErrMissingCredentials)
+	}
+	return accessToken, cookie.Value, userId, nil
+}
+
+// isRoomParticipant checks membership through chat-management first and
+// falls back to channel-management when the room is not a chat.
+func isRoomParticipant(chatMgmtClient *client.ChatMgmtGRPCClient, channelMgmtClient *client.ChanMgmtGRPCClient, roomId string, accessToken string, refreshToken string, userId string) (bool, error) {
+	_, isParticipant, err := roomParticipation(chatMgmtClient, channelMgmtClient, roomId, accessToken, refreshToken, userId)
+	return isParticipant, err
+}
+
+// roomParticipation works like isRoomParticipant and also tells whether the
+// room is a chat or a channel.
+func roomParticipation(chatMgmtClient *client.ChatMgmtGRPCClient, channelMgmtClient *client.ChanMgmtGRPCClient, roomId string, accessToken string, refreshToken string, userId string) (models.RoomType, bool, error) {
+	isParticipant, err := chatMgmtClient.PerformIsParticipant(roomId, accessToken, refreshToken, userId)
+	if err == nil {
+		return models.ChatRoomType, isParticipant, nil
+	}
+	if !isRoomNotFound(err) {
+		return "", false, err
+	}
+
+	isParticipant, err = channelMgmtClient.PerformIsParticipant(roomId, accessToken, refreshToken, userId)
+	if err == nil {
+		return models.ChannelRoomType, isParticipant, nil
+	}
+	if isRoomNotFound(err) {
+		return "", false, fmt.Errorf("%w: %v", errors.ErrRoomNotFound, roomId)
+	}
+	return "", false, err
+}
+
+// messageErrorStatus maps the errors of message operations to HTTP statuses.
+func messageErrorStatus(err error) int {
+	switch {
+	case e.Is(err, errors.ErrPermissionDenied):
+		return http.StatusForbidden
+	case e.Is(err, errors.ErrMessageNotFound), e.Is(err, errors.ErrRoomNotFound):
+		return http.StatusNotFound
+	case e.Is(err, errors.ErrDatabaseInternalError), e.Is(err, errors.ErrPublishMessageError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// roomListPageSize is the largest page the management services hand out.
+const roomListPageSize = 100
+
+// listUserRoomIds walks every page of the chats and channels of the user and
+// returns the ids of all of them.
+func listUserRoomIds(chatMgmtClient *client.ChatMgmtGRPCClient, channelMgmtClient *client.ChanMgmtGRPCClient, accessToken string, refreshToken string, userId string) ([]uuid.UUID, error) {
+	var chatRoomIds []uuid.UUID
+	pageToken := ""
+	for {
+		chats, err := chatMgmtClient.PerformListUserChats(accessToken, refreshToken, userId, roomListPageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, chat := range chats.Chats {
+			chatId, err := uuid.Parse(chat.ChatId)
+			if err != nil {
+				return nil, err
+			}
+			chatRoomIds = append(chatRoomIds, chatId)
+		}
+		if chats.NextPageToken == "" {
+			break
+		}
+		pageToken = chats.NextPageToken
+	}
+
+	pageToken = ""
+	for {
+		channels, err := channelMgmtClient.PerformListUserChannels(accessToken, refreshToken, userId, roomListPageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, channel := range channels.Channels {
+			channelId, err := uuid.Parse(channel.ChannelId)
+			if err != nil {
+				return nil, err
+			}
+			chatRoomIds = append(chatRoomIds, channelId)
+		}
+		if channels.NextPageToken == "" {
+			break
+		}
+		pageToken = channels.NextPageToken
+	}
+	return chatRoomIds, nil
+}
+
+func listErrorStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func isRoomNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
@@ -1,25 +1,418 @@
 package dto
 
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ProtocolVersion is the websocket envelope version understood by the server.
+const ProtocolVersion = 1
+
+const (
+	MessageTypeCreate = "message"
+	MessageTypeEdit   = "edit"
+	MessageTypeDelete = "delete"
+	// MessageTypeMedia announces that the attachments of a message are
+	// complete or failed to arrive in time.
+	MessageTypeMedia = "media"
+	// MessageTypeReaction carries a message with its updated reaction counts.
+	MessageTypeReaction = "reaction"
+	MessageTypePinned   = "pinned"
+	MessageTypeUnpinned = "unpinned"
+	// MessageTypePoll carries a poll message with its updated vote counts.
+	MessageTypePoll = "poll"
+
+	FrameTypeAck      = "ack"
+	FrameTypeError    = "error"
+	FrameTypeRead     = "read"
+	FrameTypeReceipt  = "receipt"
+	FrameTypeTyping   = "typing"
+	FrameTypePresence = "presence"
+
+	FrameTypeReactionAdd    = "reaction_add"
+	FrameTypeReactionRemove = "reaction_remove"
+	FrameTypePin            = "pin"
+	FrameTypeUnpin          = "unpin"
+	FrameTypeForward        = "forward"
+	FrameTypeVote           = "vote"
+)
+
+// Content types of a message. Anything but text carries the structured
+// payload of its type.
+const (
+	ContentTypeText     = "text"
+	ContentTypeLocation = "location"
+	ContentTypeContact  = "contact"
+	ContentTypeSticker  = "sticker"
+	ContentTypePoll     = "poll"
+)
+
+const (
+	PresenceStatusOnline  = "online"
+	PresenceStatusOffline = "offline"
+)
+
+const (
+	MediaStatusPending  = "pending"
+	MediaStatusComplete = "complete"
+	MediaStatusFailed   = "failed"
+)
+
+const (
+	ReceiptStatusDelivered = "delivered"
+	ReceiptStatusRead      = "read"
+)
+
+const (
+	ErrorCodeBadRequest = "bad_request"
+	ErrorCodeForbidden  = "forbidden"
+	ErrorCodeNotFound   = "not_found"
+	ErrorCodeInternal   = "internal"
+)
+
+// Envelope wraps every websocket frame in both directions. RequestId is chosen
+// by the client and echoed back in the matching ack or error frame.
+type Envelope struct {
+	Version   int             `json:"version"`
+	Type      string          `json:"type"`
+	RequestId string          `json:"requestId,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+func NewEnvelope(frameType string, requestId string, payload interface{}) (*Envelope, error) {
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		Version:   ProtocolVersion,
+		Type:      frameType,
+		RequestId: requestId,
+		Payload:   bytes,
+	}, nil
+}
+
+type AckPayload struct {
+	MessageId       string `json:"messageId"`
+	ServerTimestamp uint64 `json:"serverTimestamp"`
+}
+
+type ErrorPayload struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
 type MessageRequest struct {
+	Type       string `json:"-"`
 	MessageId  string `json:"messageId"`
 	SenderId   string `json:"senderId"`
 	ChatRoomId string `json:"chatRoomId"`
 	Body       string `json:"body"`
 	CreatedAt  uint64 `json:"createdAt"`
 	WithMedia  int    `json:"withMedia"`
+	// ReplyToId quotes another message of the room. ThreadRootId posts the
+	// message as a reply in the thread of a channel message.
+	ReplyToId    string `json:"replyToId,omitempty"`
+	ThreadRootId string `json:"threadRootId,omitempty"`
+	// ContentType defaults to text. The payload of the given type is
+	// required, the others must be empty.
+	ContentType string       `json:"contentType,omitempty"`
+	Location    *Location    `json:"location,omitempty"`
+	Contact     *Contact     `json:"contact,omitempty"`
+	Sticker     *Sticker     `json:"sticker,omitempty"`
+	Poll        *PollRequest `json:"poll,omitempty"`
+}
+
+// PollRequest creates a poll. ClosesAt, in unix milliseconds, is optional;
+// without it the poll stays open.
+type PollRequest struct {
+	Question       string   `json:"question"`
+	Options        []string `json:"options"`
+	MultipleChoice bool     `json:"multipleChoice"`
+	ClosesAt       uint64   `json:"closesAt,omitempty"`
+}
+
+// VoteRequest replaces the votes of the user in a poll with Options, the
+// indexes of the chosen options. No options takes the votes back.
+type VoteRequest struct {
+	ChatRoomId string `json:"chatRoomId"`
+	MessageId  string `json:"messageId"`
+	Options    []int  `json:"options"`
+}
+
+// ReadRequest marks every message in the room up to and including MessageId as read.
+type ReadRequest struct {
+	ChatRoomId string `json:"chatRoomId"`
+	MessageId  string `json:"messageId"`
+}
+
+// ScheduleMessageRequest queues a message until SendAt, in unix milliseconds.
+type ScheduleMessageRequest struct {
+	ChatRoomId   string `json:"chatRoomId"`
+	Body         string `json:"body"`
+	SendAt       uint64 `json:"sendAt"`
+	ReplyToId    string `json:"replyToId,omitempty"`
+	ThreadRootId string `json:"threadRootId,omitempty"`
+}
+
+// EditScheduledMessageRequest replaces the body and the send time of a
+// scheduled message. Empty fields are left unchanged.
+type EditScheduledMessageRequest struct {
+	Body   string `json:"body"`
+	SendAt uint64 `json:"sendAt"`
+}
+
+type ScheduledMessageResponse struct {
+	ScheduledMessageId string `json:"scheduledMessageId"`
+	ChatRoomId         string `json:"chatRoomId"`
+	Body               string `json:"body"`
+	SendAt             uint64 `json:"sendAt"`
+	CreatedAt          uint64 `json:"createdAt"`
+	ReplyToId          string `json:"replyToId,omitempty"`
+	ThreadRootId       string `json:"threadRootId,omitempty"`
+}
+
+type ScheduledMessagesResponse struct {
+	Messages []ScheduledMessageResponse `json:"messages"`
+}
+
+// PinRequest pins or unpins a message of the room.
+type PinRequest struct {
+	ChatRoomId string `json:"chatRoomId"`
+	MessageId  string `json:"messageId"`
+}
+
+// ForwardRequest copies MessageId of ChatRoomId into TargetChatRoomId. The
+// target is a room of the same type as the connection unless TargetRoomType
+// says otherwise.
+type ForwardRequest struct {
+	ChatRoomId       string `json:"chatRoomId"`
+	MessageId        string `json:"messageId"`
+	TargetChatRoomId string `json:"targetChatRoomId"`
+	TargetRoomType   string `json:"targetRoomType,omitempty"`
+}
+
+// ReactionRequest adds or removes the reaction of the user with Emoji.
+type ReactionRequest struct {
+	ChatRoomId string `json:"chatRoomId"`
+	MessageId  string `json:"messageId"`
+	Emoji      string `json:"emoji"`
+}
+
+type TypingRequest struct {
+	ChatRoomId string `json:"chatRoomId"`
+	Typing     bool   `json:"typing"`
+}
+
+// PresenceRequest announces that the user is present in a room. Participants
+// are told when the user goes offline again.
+type PresenceRequest struct {
+	ChatRoomId string `json:"chatRoomId"`
+}
+
+type TypingResponse struct {
+	ChatRoomId string `json:"chatRoomId"`
+	UserId     string `json:"userId"`
+	Typing     bool   `json:"typing"`
+}
+
+type PresenceResponse struct {
+	ChatRoomId string `json:"chatRoomId"`
+	UserId     string `json:"userId"`
+	Status     string `json:"status"`
+	LastSeen   uint64 `json:"lastSeen"`
+}
+
+type ReceiptResponse struct {
+	Status     string `json:"status"`
+	MessageId  string `json:"messageId"`
+	ChatRoomId string `json:"chatRoomId"`
+	UserId     string `json:"userId"`
+	Timestamp  uint64 `json:"timestamp"`
+}
+
+type UnreadCountResponse struct {
+	ChatRoomId  string `json:"chatRoomId"`
+	UnreadCount int    `json:"unreadCount"`
+}
+
+type UnreadCountsResponse struct {
+	ChatRooms []UnreadCountResponse `json:"chatRooms"`
+}
+
+type ConversationResponse struct {
+	ChatRoomId      string           `json:"chatRoomId"`
+	RoomType        string           `json:"roomType"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	IsDirect        bool             `json:"isDirect"`
+	ParticipantsIds []string         `json:"participantsIds,omitempty"`
+	LastMessage     *MessageResponse `json:"lastMessage,omitempty"`
+	UnreadCount     int              `json:"unreadCount"`
+}
+
+type InboxResponse struct {
+	Conversations         []ConversationResponse `json:"conversations"`
+	ChatsNextPageToken    string                 `json:"chatsNextPageToken,omitempty"`
+	ChannelsNextPageToken string                 `json:"channelsNextPageToken,omitempty"`
 }
 
 type MessageResponse struct {
-	MessageId  string   `json:"messageId"`
-	SenderId   string   `json:"senderId"`
-	ChatRoomId string   `json:"chatRoomId"`
-	Body       string   `json:"body"`
-	CreatedAt  uint64   `json:"createdAt"`
-	Metadata   Metadata `json:"metadata"`
+	Type          string                  `json:"type"`
+	MessageId     string                  `json:"messageId"`
+	SenderId      string                  `json:"senderId"`
+	ChatRoomId    string                  `json:"chatRoomId"`
+	Body          string                  `json:"body"`
+	CreatedAt     uint64                  `json:"createdAt"`
+	EditedAt      uint64                  `json:"editedAt,omitempty"`
+	Deleted       bool                    `json:"deleted,omitempty"`
+	Metadata      Metadata                `json:"metadata"`
+	ReplyToId     string                  `json:"replyToId,omitempty"`
+	ReplyTo       *ReplyPreviewResponse   `json:"replyTo,omitempty"`
+	ThreadRootId  string                  `json:"threadRootId,omitempty"`
+	Thread        *ThreadSummaryResponse  `json:"thread,omitempty"`
+	Reactions     []ReactionCountResponse `json:"reactions,omitempty"`
+	Pin           *PinResponse            `json:"pin,omitempty"`
+	ForwardedFrom *ForwardedFromResponse  `json:"forwardedFrom,omitempty"`
+	ExpiresAt     uint64                  `json:"expiresAt,omitempty"`
+	ContentType   string                  `json:"contentType"`
+	Location      *Location               `json:"location,omitempty"`
+	Contact       *Contact                `json:"contact,omitempty"`
+	Sticker       *Sticker                `json:"sticker,omitempty"`
+	Poll          *PollResponse           `json:"poll,omitempty"`
+}
+
+type PollResponse struct {
+	Question       string               `json:"question"`
+	Options        []PollOptionResponse `json:"options"`
+	MultipleChoice bool                 `json:"multipleChoice"`
+	ClosesAt       uint64               `json:"closesAt,omitempty"`
+	Closed         bool                 `json:"closed"`
+	VoterCount     int                  `json:"voterCount"`
+}
+
+type PollOptionResponse struct {
+	Text  string `json:"text"`
+	Votes int    `json:"votes"`
+}
+
+// ForwardedFromResponse names the original sender, room and send time of a
+// forwarded message.
+type ForwardedFromResponse struct {
+	SenderId   string `json:"senderId"`
+	ChatRoomId string `json:"chatRoomId"`
+	CreatedAt  uint64 `json:"createdAt"`
+}
+
+type PinResponse struct {
+	PinnedBy string `json:"pinnedBy"`
+	PinnedAt uint64 `json:"pinnedAt"`
+}
+
+// PinsResponse lists the pinned messages of a room, latest pin first.
+type PinsResponse struct {
+	Messages []MessageResponse `json:"messages"`
+}
+
+type ReactionCountResponse struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// ReplyPreviewResponse shows the beginning of the message a reply quotes.
+type ReplyPreviewResponse struct {
+	MessageId string `json:"messageId"`
+	SenderId  string `json:"senderId"`
+	Body      string `json:"body"`
+	Deleted   bool   `json:"deleted,omitempty"`
+}
+
+type ThreadSummaryResponse struct {
+	ReplyCount  int    `json:"replyCount"`
+	LastReplyAt uint64 `json:"lastReplyAt,omitempty"`
 }
 
 type Metadata struct {
-	FilePath string `json:"filePath"`
+	MediaStatus string       `json:"mediaStatus,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Content is the structured payload of a location, contact or sticker
+// message. Polls are kept in their own tables.
+type Content struct {
+	Location *Location `json:"location,omitempty"`
+	Contact  *Contact  `json:"contact,omitempty"`
+	Sticker  *Sticker  `json:"sticker,omitempty"`
+}
+
+// Location is a point in WGS 84 degrees with an optional place name.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// Contact is a contact card. UserId links it to an account of the app.
+type Contact struct {
+	Name   string `json:"name"`
+	Phone  string `json:"phone,omitempty"`
+	Email  string `json:"email,omitempty"`
+	UserId string `json:"userId,omitempty"`
+}
+
+type Sticker struct {
+	PackId    string `json:"packId"`
+	StickerId string `json:"stickerId"`
+}
+
+type Attachment struct {
+	FileId      string `json:"fileId"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// Value stores Metadata in its jsonb column.
+func (m Metadata) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+func (m *Metadata) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Metadata{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	default:
+		return fmt.Errorf("can not scan %T into Metadata", value)
+	}
+}
+
+// Value stores Content in its jsonb column.
+func (c Content) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *Content) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = Content{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("can not scan %T into Content", value)
+	}
 }
 
 func MapRequestToResponse(req MessageRequest) *MessageResponse {
@@ -31,6 +424,75 @@ func MapRequestToResponse(req MessageRequest) *MessageResponse {
 	return resp
 }
 
+type HistoryRequest struct {
+	Before string
+	After  string
+	Limit  int
+}
+
 type HistoryResponse struct {
-	Messages []MessageResponse `json:"messages"`
+	Messages   []MessageResponse `json:"messages"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	PrevCursor string            `json:"prevCursor,omitempty"`
+}
+
+// SearchRequest filters a message search. From and To bound CreatedAt and
+// are ignored when zero.
+type SearchRequest struct {
+	Query         string
+	ChatRoomId    string
+	SenderId      string
+	From          uint64
+	To            uint64
+	HasAttachment bool
+	Cursor        string
+	Limit         int
+}
+
+// SearchResultResponse carries a matching message with its highlighted
+// snippet. HistoryCursor can be passed as before or after to the history of
+// the message room to load the conversation around it.
+type SearchResultResponse struct {
+	Message       MessageResponse `json:"message"`
+	Snippet       string          `json:"snippet"`
+	HistoryCursor string          `json:"historyCursor"`
+}
+
+type SearchResponse struct {
+	Results    []SearchResultResponse `json:"results"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+}
+
+// ThreadResponse lists the replies under a thread root. The root carries the
+// reply count of the whole thread.
+type ThreadResponse struct {
+	Root       MessageResponse   `json:"root"`
+	Replies    []MessageResponse `json:"replies"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	PrevCursor string            `json:"prevCursor,omitempty"`
+}
+
+// HistoryCursor points at a single message in a chat room history.
+// Messages are ordered by (CreatedAt, Id), so the pair is unique and stable.
+type HistoryCursor struct {
+	CreatedAt uint64    `json:"createdAt"`
+	Id        uuid.UUID `json:"id"`
+}
+
+func EncodeCursor(cursor HistoryCursor) string {
+	bytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func DecodeCursor(value string) (*HistoryCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	cursor := &HistoryCursor{}
+	err = json.Unmarshal(bytes, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return cursor, nil
 }
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor HistoryCursor
+	}{
+		{name: "zero", cursor: HistoryCursor{}},
+		{name: "message", cursor: HistoryCursor{CreatedAt: 1700000000123, Id: uuid.New()}},
+		{name: "largest time", cursor: HistoryCursor{CreatedAt: ^uint64(0), Id: uuid.New()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := EncodeCursor(tt.cursor)
+			decoded, err := DecodeCursor(encoded)
+			if err != nil {
+				t.Fatalf("DecodeCursor(%q) = %v", encoded, err)
+			}
+			if *decoded != tt.cursor {
+				t.Fatalf("DecodeCursor(EncodeCursor(%+v)) = %+v", tt.cursor, *decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "not base64", value: "not a cursor!"},
+		{name: "padded base64", value: "e30="},
+		{name: "not json", value: "bm90IGpzb24"},
+		{name: "bad id", value: "eyJpZCI6ImFiYyJ9"},
+		{name: "negative time", value: "eyJjcmVhdGVkQXQiOi0xfQ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := DecodeCursor(tt.value); err == nil {
+				t.Fatalf("DecodeCursor(%q) = %+v, want an error", tt.value, *cursor)
+			}
+		})
+	}
+}
@@ -19,6 +19,42 @@ var (
 	ErrSetStatusRedis = fmt.Errorf("set user status redis error")
 
 	ErrDropStatusRedis = fmt.Errorf("drop user status redis error")
+
+	ErrInvalidCursor = errors.New("invalid history cursor")
+
+	ErrInvalidLimit = errors.New("invalid history limit")
+
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+
+	ErrMissingCredentials = errors.New("missing credentials")
+
+	ErrRoomNotFound = errors.New("chat room not found")
+
+	ErrMessageNotFound = errors.New("message not found")
+
+	ErrPermissionDenied = errors.New("permission denied")
+
+	ErrInvalidReply = errors.New("invalid reply")
+
+	ErrInvalidReaction = errors.New("invalid reaction")
+
+	ErrTooManyPins = errors.New("too many pinned messages")
+
+	ErrInvalidSchedule = errors.New("invalid schedule")
+
+	ErrInvalidForward = errors.New("invalid forward")
+
+	ErrInvalidContent = errors.New("invalid message content")
+
+	ErrInvalidVote = errors.New("invalid vote")
+
+	ErrUnsupportedVersion = errors.New("unsupported protocol version")
+
+	ErrUnknownFrameType = errors.New("unknown frame type")
+
+	ErrSessionClosed = errors.New("websocket session closed")
+
+	ErrSlowConsumer = errors.New("websocket session too slow")
 )
 
 func IsDatabaseInternalError(err error) bool {
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"example.com/chat-app/src/internal/dto"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
@@ -13,24 +15,386 @@ type ChatRoomXUser struct {
 }
 
 type MessageIdXFileId struct {
-	MessageId uuid.UUID `json:"messageId"`
-	FileId    uuid.UUID `json:"fileId"`
+	MessageId   uuid.UUID `json:"messageId"`
+	FileId      uuid.UUID `json:"fileId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+}
+
+// ChatDeletedEvent is published by chat-management once a chat is gone.
+// DeletedAt is in unix milliseconds.
+type ChatDeletedEvent struct {
+	ChatId    uuid.UUID `json:"chatId"`
+	DeletedBy uuid.UUID `json:"deletedBy"`
+	DeletedAt uint64    `json:"deletedAt"`
+}
+
+// MessageExpiredEvent is published once a disappearing message is reaped so
+// media-handler drops its attachments.
+type MessageExpiredEvent struct {
+	MessageId  uuid.UUID `json:"messageId"`
+	ChatRoomId uuid.UUID `json:"chatRoomId"`
+	ExpiredAt  uint64    `json:"expiredAt"`
+}
+
+// MessageForwardedEvent is published when a message with attachments is
+// forwarded, so media-handler keeps the shared files while the copy exists.
+type MessageForwardedEvent struct {
+	MessageId   uuid.UUID `json:"messageId"`
+	ChatRoomId  uuid.UUID `json:"chatRoomId"`
+	FileIds     []string  `json:"fileIds"`
+	ForwardedAt uint64    `json:"forwardedAt"`
+}
+
+func MapFileToAttachment(mf *MessageIdXFileId) dto.Attachment {
+	return dto.Attachment{
+		FileId:      mf.FileId.String(),
+		FileName:    mf.FileName,
+		ContentType: mf.ContentType,
+		Size:        mf.Size,
+	}
+}
+
+// PendingMedia is kept in Redis while a message waits for its attachments.
+// It holds everything needed to publish the completed message later.
+type PendingMedia struct {
+	RoomType          RoomType          `json:"room_type"`
+	MessageWithTokens MessageWithTokens `json:"message_with_tokens"`
 }
 
 type Message struct {
 	Id         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
 	SenderId   uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
-	ChatRoomId uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	ChatRoomId uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();index:idx_messages_chat_room_id_created_at"`
 	Body       string
+	// ContentType tells how to show the message. The payload of location,
+	// contact and sticker messages is kept in Content, polls in their own
+	// tables.
+	ContentType string      `gorm:"default:'text'"`
+	Content     dto.Content `gorm:"type:jsonb"`
+	CreatedAt   uint64      `gorm:"index:idx_messages_chat_room_id_created_at"`
+	WithMedia   int
+	Metadata    dto.Metadata `gorm:"type:jsonb"`
+	EditedAt    uint64
+	IsDeleted   bool
+	Revisions   []MessageRevision `gorm:"foreignkey:MessageId"`
+	// ReplyToId is the message this one quotes. ThreadRootId places the
+	// message in the thread under a channel message instead of the channel
+	// history itself.
+	ReplyToId    *uuid.UUID `gorm:"type:uuid"`
+	ThreadRootId *uuid.UUID `gorm:"type:uuid;index"`
+	// ForwardedFrom* record where a forwarded message was first sent: the
+	// original sender, room and send time.
+	ForwardedFromSenderId  *uuid.UUID `gorm:"type:uuid"`
+	ForwardedFromRoomId    *uuid.UUID `gorm:"type:uuid"`
+	ForwardedFromCreatedAt uint64
+	// ExpiresAt is set in chats with a message TTL. The message is deleted
+	// for everyone once it has passed.
+	ExpiresAt uint64 `gorm:"index"`
+	// ReplyPreview and Thread are filled for clients and never persisted.
+	ReplyPreview *ReplyPreview   `gorm:"-"`
+	Thread       *ThreadSummary  `gorm:"-"`
+	Reactions    []ReactionCount `gorm:"-"`
+	Pin          *PinnedMessage  `gorm:"-"`
+	Poll         *Poll           `gorm:"-"`
+}
+
+// Poll belongs to the poll message with the same id. Options are numbered
+// from zero in the order they were given.
+type Poll struct {
+	MessageId      uuid.UUID `gorm:"type:uuid;primary_key"`
+	ChatRoomId     uuid.UUID `gorm:"type:uuid;index"`
+	Question       string
+	MultipleChoice bool
+	ClosesAt       uint64
+	CreatedAt      uint64
+	// Options and VoterCount are filled for clients.
+	Options    []PollOption `gorm:"-"`
+	VoterCount int          `gorm:"-"`
+}
+
+func (p *Poll) IsClosed(now uint64) bool {
+	return p.ClosesAt != 0 && p.ClosesAt <= now
+}
+
+type PollOption struct {
+	MessageId uuid.UUID `gorm:"type:uuid;unique_index:idx_poll_options_message_id_position"`
+	Position  int       `gorm:"unique_index:idx_poll_options_message_id_position"`
+	Text      string
+	Votes     int `gorm:"-"`
+}
+
+// PollVote is the vote of a user for one option. A multiple choice poll has
+// a vote per chosen option.
+type PollVote struct {
+	MessageId  uuid.UUID `gorm:"type:uuid;unique_index:idx_poll_votes_message_id_user_id_position"`
+	UserId     uuid.UUID `gorm:"type:uuid;unique_index:idx_poll_votes_message_id_user_id_position"`
+	Position   int       `gorm:"unique_index:idx_poll_votes_message_id_user_id_position"`
+	ChatRoomId uuid.UUID `gorm:"type:uuid;index"`
+	VotedAt    uint64
+}
+
+// PollOptionCount is how many users voted for an option.
+type PollOptionCount struct {
+	MessageId uuid.UUID
+	Position  int
+	Votes     int
+}
+
+// PollVoterCount is how many users voted in a poll.
+type PollVoterCount struct {
+	MessageId uuid.UUID
+	Voters    int
+}
+
+// ScheduledMessage waits until SendAt and is then sent as a normal message
+// with the same id. No tokens of the sender are kept; the sender is checked
+// again with the service token when the message is sent.
+type ScheduledMessage struct {
+	Id           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	SenderId     uuid.UUID `gorm:"type:uuid;index"`
+	ChatRoomId   uuid.UUID `gorm:"type:uuid"`
+	RoomType     RoomType
+	Body         string
+	ReplyToId    *uuid.UUID `gorm:"type:uuid"`
+	ThreadRootId *uuid.UUID `gorm:"type:uuid"`
+	SendAt       uint64     `gorm:"index"`
+	CreatedAt    uint64
+}
+
+// ForwardTo copies the message into another room as a new message of
+// senderId. The attachments are shared with the original. Forwarding a
+// forwarded message keeps the first origin.
+func (message *Message) ForwardTo(senderId uuid.UUID, chatRoomId uuid.UUID, createdAt uint64) *Message {
+	originalSenderId, originalRoomId := message.SenderId, message.ChatRoomId
+	forwarded := &Message{
+		Id:                     uuid.New(),
+		SenderId:               senderId,
+		ChatRoomId:             chatRoomId,
+		Body:                   message.Body,
+		ContentType:            message.ContentType,
+		Content:                message.Content,
+		CreatedAt:              createdAt,
+		WithMedia:              message.WithMedia,
+		Metadata:               message.Metadata,
+		ForwardedFromSenderId:  &originalSenderId,
+		ForwardedFromRoomId:    &originalRoomId,
+		ForwardedFromCreatedAt: message.CreatedAt,
+	}
+	if message.ForwardedFromSenderId != nil {
+		forwarded.ForwardedFromSenderId = message.ForwardedFromSenderId
+		forwarded.ForwardedFromRoomId = message.ForwardedFromRoomId
+		forwarded.ForwardedFromCreatedAt = message.ForwardedFromCreatedAt
+	}
+	return forwarded
+}
+
+func (s *ScheduledMessage) ToMessage() *Message {
+	return &Message{
+		Id:           s.Id,
+		SenderId:     s.SenderId,
+		ChatRoomId:   s.ChatRoomId,
+		Body:         s.Body,
+		ContentType:  dto.ContentTypeText,
+		ReplyToId:    s.ReplyToId,
+		ThreadRootId: s.ThreadRootId,
+	}
+}
+
+func MapScheduledMessageToResponse(scheduled *ScheduledMessage) dto.ScheduledMessageResponse {
+	scheduledResp := dto.ScheduledMessageResponse{
+		ScheduledMessageId: scheduled.Id.String(),
+		ChatRoomId:         scheduled.ChatRoomId.String(),
+		Body:               scheduled.Body,
+		SendAt:             scheduled.SendAt,
+		CreatedAt:          scheduled.CreatedAt,
+	}
+	if scheduled.ReplyToId != nil {
+		scheduledResp.ReplyToId = scheduled.ReplyToId.String()
+	}
+	if scheduled.ThreadRootId != nil {
+		scheduledResp.ThreadRootId = scheduled.ThreadRootId.String()
+	}
+	return scheduledResp
+}
+
+// PinnedMessage marks a message pinned in its room.
+type PinnedMessage struct {
+	ChatRoomId uuid.UUID `gorm:"type:uuid;primary_key"`
+	MessageId  uuid.UUID `gorm:"type:uuid;primary_key"`
+	PinnedBy   uuid.UUID `gorm:"type:uuid"`
+	PinnedAt   uint64
+}
+
+// MessageReaction is one emoji a user reacted to a message with.
+type MessageReaction struct {
+	MessageId  uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserId     uuid.UUID `gorm:"type:uuid;primary_key"`
+	Emoji      string    `gorm:"primary_key"`
+	ChatRoomId uuid.UUID `gorm:"type:uuid;index"`
 	CreatedAt  uint64
-	WithMedia  int
-	Metadata   dto.Metadata `gorm:"type:jsonb"`
 }
 
+// ReactionCount is how many users reacted to a message with Emoji.
+type ReactionCount struct {
+	MessageId uuid.UUID
+	Emoji     string
+	Count     int
+}
+
+// ReplyPreviewLength is how many characters of a replied-to body are shown.
+const ReplyPreviewLength = 100
+
+// ReplyPreview is the part of a replied-to message shown above the reply.
+type ReplyPreview struct {
+	MessageId uuid.UUID
+	SenderId  uuid.UUID
+	Body      string
+	IsDeleted bool
+}
+
+func NewReplyPreview(message *Message) *ReplyPreview {
+	body := []rune(message.Body)
+	if len(body) > ReplyPreviewLength {
+		body = body[:ReplyPreviewLength]
+	}
+	return &ReplyPreview{
+		MessageId: message.Id,
+		SenderId:  message.SenderId,
+		Body:      string(body),
+		IsDeleted: message.IsDeleted,
+	}
+}
+
+// ThreadSummary counts the replies of a thread root.
+type ThreadSummary struct {
+	RootId      uuid.UUID
+	ReplyCount  int
+	LastReplyAt uint64
+}
+
+// MessageRevision keeps the body a message had before it was edited.
+type MessageRevision struct {
+	Id        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	MessageId uuid.UUID `gorm:"type:uuid;index"`
+	EditorId  uuid.UUID `gorm:"type:uuid"`
+	Body      string
+	EditedAt  uint64
+}
+
+// MessageReceipt tracks delivery and read state of one message for one recipient.
+type MessageReceipt struct {
+	MessageId   uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserId      uuid.UUID `gorm:"type:uuid;primary_key"`
+	ChatRoomId  uuid.UUID `gorm:"type:uuid;index"`
+	DeliveredAt uint64
+	ReadAt      uint64
+}
+
+type ReceiptEvent struct {
+	Status      string      `json:"status"`
+	MessageId   uuid.UUID   `json:"message_id"`
+	ChatRoomId  uuid.UUID   `json:"chat_room_id"`
+	UserId      uuid.UUID   `json:"user_id"`
+	Timestamp   uint64      `json:"timestamp"`
+	ReceiverIds []uuid.UUID `json:"receiver_ids"`
+}
+
+// EphemeralEvent carries typing and presence changes. It is only fanned out
+// through Redis and never persisted.
+type EphemeralEvent struct {
+	Type         string    `json:"type"`
+	RoomType     RoomType  `json:"room_type"`
+	ChatRoomId   uuid.UUID `json:"chat_room_id"`
+	UserId       uuid.UUID `json:"user_id"`
+	Typing       bool      `json:"typing"`
+	Status       string    `json:"status"`
+	LastSeen     uint64    `json:"last_seen"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+func MapEphemeralEventToResponse(event *EphemeralEvent) interface{} {
+	if event.Type == dto.FrameTypeTyping {
+		return &dto.TypingResponse{
+			ChatRoomId: event.ChatRoomId.String(),
+			UserId:     event.UserId.String(),
+			Typing:     event.Typing,
+		}
+	}
+	return &dto.PresenceResponse{
+		ChatRoomId: event.ChatRoomId.String(),
+		UserId:     event.UserId.String(),
+		Status:     event.Status,
+		LastSeen:   event.LastSeen,
+	}
+}
+
+// RoutedEvent is forwarded to the instance holding the receivers' websocket
+// connections. It carries either a message event or a prepared envelope.
+type RoutedEvent struct {
+	ReceiverIds  []uuid.UUID   `json:"receiver_ids"`
+	ReadyMessage *ReadyMessage `json:"ready_message,omitempty"`
+	Envelope     *dto.Envelope `json:"envelope,omitempty"`
+}
+
+type UnreadCount struct {
+	ChatRoomId uuid.UUID
+	Count      int
+}
+
+// Conversation is one inbox entry: a room of the user together with its
+// latest message and the number of messages the user has not read yet.
+type Conversation struct {
+	ChatRoomId      uuid.UUID
+	RoomType        RoomType
+	Name            string
+	Description     string
+	IsDirect        bool
+	ParticipantsIds []string
+	LastMessage     *Message
+	UnreadCount     int
+}
+
+func MapConversationToResponse(conversation *Conversation) dto.ConversationResponse {
+	conversationResp := dto.ConversationResponse{
+		ChatRoomId:      conversation.ChatRoomId.String(),
+		RoomType:        string(conversation.RoomType),
+		Name:            conversation.Name,
+		Description:     conversation.Description,
+		IsDirect:        conversation.IsDirect,
+		ParticipantsIds: conversation.ParticipantsIds,
+		UnreadCount:     conversation.UnreadCount,
+	}
+	if conversation.LastMessage != nil {
+		conversationResp.LastMessage = MapMessageToResponse(conversation.LastMessage)
+	}
+	return conversationResp
+}
+
+// Permissions granted by member roles in chat-management and
+// channel-management.
+const (
+	PermissionPost           = "post"
+	PermissionPin            = "pin"
+	PermissionEditMessages   = "edit_messages"
+	PermissionDeleteMessages = "delete_messages"
+)
+
+type RoomType string
+
+const (
+	ChatRoomType    RoomType = "chat"
+	ChannelRoomType RoomType = "channel"
+)
+
 type MessageWithTokens struct {
-	Message      Message `json:"message"`
-	AccessToken  string  `json:"access_token"`
-	RefreshToken string  `json:"refresh_token"`
+	Type         string    `json:"type"`
+	Message      Message   `json:"message"`
+	ActorId      uuid.UUID `json:"actor_id"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
 }
 
 func MapRequestToMessage(req *dto.MessageRequest) (*Message, error) {
@@ -46,27 +410,187 @@ func MapRequestToMessage(req *dto.MessageRequest) (*Message, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Message{
-		Id:         messageUUID,
-		SenderId:   senderUUID,
-		ChatRoomId: chatRoomUUID,
-		Body:       req.Body,
-		CreatedAt:  req.CreatedAt,
-		WithMedia:  req.WithMedia,
-	}, nil
+	message := &Message{
+		Id:          messageUUID,
+		SenderId:    senderUUID,
+		ChatRoomId:  chatRoomUUID,
+		Body:        req.Body,
+		ContentType: req.ContentType,
+		Content: dto.Content{
+			Location: req.Location,
+			Contact:  req.Contact,
+			Sticker:  req.Sticker,
+		},
+		CreatedAt: req.CreatedAt,
+		WithMedia: req.WithMedia,
+	}
+	if message.ContentType == "" {
+		message.ContentType = dto.ContentTypeText
+	}
+	if req.Poll != nil {
+		message.Poll = &Poll{
+			MessageId:      messageUUID,
+			ChatRoomId:     chatRoomUUID,
+			Question:       req.Poll.Question,
+			MultipleChoice: req.Poll.MultipleChoice,
+			ClosesAt:       req.Poll.ClosesAt,
+			CreatedAt:      req.CreatedAt,
+		}
+		for i, text := range req.Poll.Options {
+			message.Poll.Options = append(message.Poll.Options, PollOption{MessageId: messageUUID, Position: i, Text: text})
+		}
+	}
+	if req.ReplyToId != "" {
+		replyToUUID, err := uuid.Parse(req.ReplyToId)
+		if err != nil {
+			return nil, err
+		}
+		message.ReplyToId = &replyToUUID
+	}
+	if req.ThreadRootId != "" {
+		threadRootUUID, err := uuid.Parse(req.ThreadRootId)
+		if err != nil {
+			return nil, err
+		}
+		message.ThreadRootId = &threadRootUUID
+	}
+	return message, nil
+}
+
+func MapReceiptEventToResponse(event *ReceiptEvent) *dto.ReceiptResponse {
+	return &dto.ReceiptResponse{
+		Status:     event.Status,
+		MessageId:  event.MessageId.String(),
+		ChatRoomId: event.ChatRoomId.String(),
+		UserId:     event.UserId.String(),
+		Timestamp:  event.Timestamp,
+	}
 }
 
 func MapMessageToResponse(message *Message) *dto.MessageResponse {
 	messageId := message.Id.String()
 	senderId := message.SenderId.String()
 	chatRoomId := message.ChatRoomId.String()
-	return &dto.MessageResponse{
-		MessageId:  messageId,
-		SenderId:   senderId,
-		ChatRoomId: chatRoomId,
-		Body:       message.Body,
-		CreatedAt:  message.CreatedAt,
-		Metadata:   message.Metadata,
+	messageResp := &dto.MessageResponse{
+		Type:        dto.MessageTypeCreate,
+		MessageId:   messageId,
+		SenderId:    senderId,
+		ChatRoomId:  chatRoomId,
+		Body:        message.Body,
+		CreatedAt:   message.CreatedAt,
+		EditedAt:    message.EditedAt,
+		Deleted:     message.IsDeleted,
+		Metadata:    message.Metadata,
+		ExpiresAt:   message.ExpiresAt,
+		ContentType: message.ContentType,
+		Location:    message.Content.Location,
+		Contact:     message.Content.Contact,
+		Sticker:     message.Content.Sticker,
+	}
+	if messageResp.ContentType == "" {
+		messageResp.ContentType = dto.ContentTypeText
+	}
+	if message.Poll != nil {
+		messageResp.Poll = MapPollToResponse(message.Poll)
+	}
+	if message.ReplyToId != nil {
+		messageResp.ReplyToId = message.ReplyToId.String()
+	}
+	if message.ReplyPreview != nil {
+		messageResp.ReplyTo = &dto.ReplyPreviewResponse{
+			MessageId: message.ReplyPreview.MessageId.String(),
+			SenderId:  message.ReplyPreview.SenderId.String(),
+			Body:      message.ReplyPreview.Body,
+			Deleted:   message.ReplyPreview.IsDeleted,
+		}
+	}
+	if message.ThreadRootId != nil {
+		messageResp.ThreadRootId = message.ThreadRootId.String()
+	}
+	if message.ForwardedFromSenderId != nil && message.ForwardedFromRoomId != nil {
+		messageResp.ForwardedFrom = &dto.ForwardedFromResponse{
+			SenderId:   message.ForwardedFromSenderId.String(),
+			ChatRoomId: message.ForwardedFromRoomId.String(),
+			CreatedAt:  message.ForwardedFromCreatedAt,
+		}
+	}
+	if message.Thread != nil {
+		messageResp.Thread = &dto.ThreadSummaryResponse{
+			ReplyCount:  message.Thread.ReplyCount,
+			LastReplyAt: message.Thread.LastReplyAt,
+		}
+	}
+	if message.Pin != nil {
+		messageResp.Pin = &dto.PinResponse{
+			PinnedBy: message.Pin.PinnedBy.String(),
+			PinnedAt: message.Pin.PinnedAt,
+		}
+	}
+	for _, reaction := range message.Reactions {
+		messageResp.Reactions = append(messageResp.Reactions, dto.ReactionCountResponse{
+			Emoji: reaction.Emoji,
+			Count: reaction.Count,
+		})
+	}
+	return messageResp
+}
+
+func MapPollToResponse(poll *Poll) *dto.PollResponse {
+	pollResp := &dto.PollResponse{
+		Question:       poll.Question,
+		Options:        make([]dto.PollOptionResponse, 0, len(poll.Options)),
+		MultipleChoice: poll.MultipleChoice,
+		ClosesAt:       poll.ClosesAt,
+		Closed:         poll.IsClosed(uint64(time.Now().UnixMilli())),
+		VoterCount:     poll.VoterCount,
+	}
+	for _, option := range poll.Options {
+		pollResp.Options = append(pollResp.Options, dto.PollOptionResponse{Text: option.Text, Votes: option.Votes})
+	}
+	return pollResp
+}
+
+type HistoryPage struct {
+	Messages   []Message
+	NextCursor string
+	PrevCursor string
+}
+
+// ThreadPage is one page of the replies under Root in chronological order.
+type ThreadPage struct {
+	Root Message
+	HistoryPage
+}
+
+// MessageSearchFilter narrows a full-text search to the rooms of the caller.
+// Zero values leave the sender and the date bounds unrestricted; Before
+// continues the search below a previous result.
+type MessageSearchFilter struct {
+	ChatRoomIds   []uuid.UUID
+	TsQuery       string
+	SenderId      uuid.UUID
+	From          uint64
+	To            uint64
+	HasAttachment bool
+	Before        *dto.HistoryCursor
+}
+
+// MessageSearchHit is a matching message together with its highlighted body.
+type MessageSearchHit struct {
+	Message
+	Snippet string
+}
+
+type SearchPage struct {
+	Hits       []MessageSearchHit
+	NextCursor string
+}
+
+func MapSearchHitToResponse(hit *MessageSearchHit) dto.SearchResultResponse {
+	return dto.SearchResultResponse{
+		Message:       *MapMessageToResponse(&hit.Message),
+		Snippet:       hit.Snippet,
+		HistoryCursor: dto.EncodeCursor(dto.HistoryCursor{CreatedAt: hit.CreatedAt, Id: hit.Id}),
 	}
 }
 
@@ -75,6 +599,7 @@ type ErrorMessageResponse struct {
 }
 
 type ReadyMessage struct {
+	Type         string      `json:"type"`
 	Message      Message     `json:"message"`
 	AccessToken  string      `json:"access_token"`
 	RefreshToken string      `json:"refresh_token"`
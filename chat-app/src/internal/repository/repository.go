@@ -2,8 +2,16 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"example.com/chat-app/src/internal/dto"
+	"example.com/chat-app/src/internal/errors"
 	"example.com/chat-app/src/internal/models"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
@@ -26,16 +34,636 @@ func (r *MessageRepository) SaveUserMessage(message *models.Message) error {
 	if r.DB == nil {
 		slog.Error("Database is not initialized")
 	}
-	err := r.DB.Begin().Create(message).Commit().Error
-	return err
+	tx := r.DB.Begin()
+	if err := tx.Create(message).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if message.Poll != nil {
+		if err := tx.Create(message.Poll).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for i := range message.Poll.Options {
+			if err := tx.Create(&message.Poll.Options[i]).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	return tx.Commit().Error
+}
+
+func (r *MessageRepository) GetMessageById(id uuid.UUID) (*models.Message, error) {
+	var message models.Message
+	err := r.DB.Where("id = ?", id).First(&message).Error
+	return &message, err
+}
+
+// EditMessage stores the current body as a revision and replaces it in one transaction.
+func (r *MessageRepository) EditMessage(message *models.Message, editorId uuid.UUID, body string, editedAt uint64) error {
+	tx := r.DB.Begin()
+	revision := &models.MessageRevision{
+		MessageId: message.Id,
+		EditorId:  editorId,
+		Body:      message.Body,
+		EditedAt:  editedAt,
+	}
+	if err := tx.Create(revision).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	err := tx.Model(&models.Message{}).Where("id = ?", message.Id).Updates(map[string]interface{}{
+		"body":      body,
+		"edited_at": editedAt,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	message.Body = body
+	message.EditedAt = editedAt
+	return nil
+}
+
+// TombstoneMessage keeps the row so history cursors stay valid but drops its
+// content, attachments and edit history. A deleted message does not stay
+// pinned.
+func (r *MessageRepository) TombstoneMessage(message *models.Message) error {
+	tx := r.DB.Begin()
+	err := tx.Model(&models.Message{}).Where("id = ?", message.Id).Updates(map[string]interface{}{
+		"body":       "",
+		"content":    dto.Content{},
+		"metadata":   dto.Metadata{},
+		"with_media": 0,
+		"is_deleted": true,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("message_id = ?", message.Id).Delete(&models.MessageRevision{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("message_id = ?", message.Id).Delete(&models.PinnedMessage{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := deletePoll(tx, message.Id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	message.Body = ""
+	message.Content = dto.Content{}
+	message.Metadata = dto.Metadata{}
+	message.WithMedia = 0
+	message.Poll = nil
+	message.IsDeleted = true
+	return nil
+}
+
+// DeleteChatRoomMessages purges the messages of a room together with their
+// revisions, receipts and the messages still scheduled for it in one
+// transaction.
+func (r *MessageRepository) DeleteChatRoomMessages(chatRoomId uuid.UUID) error {
+	tx := r.DB.Begin()
+	err := tx.Where("message_id IN (SELECT id FROM messages WHERE chat_room_id = ?)", chatRoomId).
+		Delete(&models.MessageRevision{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_room_id = ?", chatRoomId).Delete(&models.MessageReceipt{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_room_id = ?", chatRoomId).Delete(&models.MessageReaction{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_room_id = ?", chatRoomId).Delete(&models.PinnedMessage{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_room_id = ?", chatRoomId).Delete(&models.PollVote{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.Where("message_id IN (SELECT message_id FROM polls WHERE chat_room_id = ?)", chatRoomId).
+		Delete(&models.PollOption{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_room_id = ?", chatRoomId).Delete(&models.Poll{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_room_id = ?", chatRoomId).Delete(&models.Message{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_room_id = ?", chatRoomId).Delete(&models.ScheduledMessage{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r *MessageRepository) GetExpiredMessages(now uint64, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.DB.Where("expires_at > 0 AND expires_at <= ?", now).Order("expires_at, id").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// DeleteExpiredMessage removes a disappearing message together with its
+// revisions, receipts, reactions and pin. It reports false when the message
+// was already deleted by someone else.
+func (r *MessageRepository) DeleteExpiredMessage(messageId uuid.UUID) (bool, error) {
+	tx := r.DB.Begin()
+	result := tx.Where("id = ?", messageId).Delete(&models.Message{})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+	if err := tx.Where("message_id = ?", messageId).Delete(&models.MessageRevision{}).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := tx.Where("message_id = ?", messageId).Delete(&models.MessageReceipt{}).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := tx.Where("message_id = ?", messageId).Delete(&models.MessageReaction{}).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := tx.Where("message_id = ?", messageId).Delete(&models.PinnedMessage{}).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := deletePoll(tx, messageId); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return true, tx.Commit().Error
+}
+
+// deletePoll removes the poll of a message with its options and votes.
+func deletePoll(tx *gorm.DB, messageId uuid.UUID) error {
+	if err := tx.Where("message_id = ?", messageId).Delete(&models.PollVote{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("message_id = ?", messageId).Delete(&models.PollOption{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("message_id = ?", messageId).Delete(&models.Poll{}).Error
+}
+
+// GetPolls returns the polls of the messages with their options, vote counts
+// and number of voters.
+func (r *MessageRepository) GetPolls(messageIds []uuid.UUID) ([]models.Poll, error) {
+	var polls []models.Poll
+	if err := r.DB.Where("message_id IN (?)", messageIds).Find(&polls).Error; err != nil {
+		return nil, err
+	}
+	if len(polls) == 0 {
+		return polls, nil
+	}
+	var options []models.PollOption
+	err := r.DB.Where("message_id IN (?)", messageIds).Order("message_id, position").Find(&options).Error
+	if err != nil {
+		return nil, err
+	}
+	var counts []models.PollOptionCount
+	err = r.DB.Raw(
+		`SELECT message_id, position, COUNT(*) AS votes FROM poll_votes
+		WHERE message_id IN (?)
+		GROUP BY message_id, position`,
+		messageIds,
+	).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	var voters []models.PollVoterCount
+	err = r.DB.Raw(
+		`SELECT message_id, COUNT(DISTINCT user_id) AS voters FROM poll_votes
+		WHERE message_id IN (?)
+		GROUP BY message_id`,
+		messageIds,
+	).Scan(&voters).Error
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make(map[uuid.UUID]map[int]int, len(polls))
+	for _, count := range counts {
+		if votes[count.MessageId] == nil {
+			votes[count.MessageId] = make(map[int]int)
+		}
+		votes[count.MessageId][count.Position] = count.Votes
+	}
+	optionsByPoll := make(map[uuid.UUID][]models.PollOption, len(polls))
+	for _, option := range options {
+		option.Votes = votes[option.MessageId][option.Position]
+		optionsByPoll[option.MessageId] = append(optionsByPoll[option.MessageId], option)
+	}
+	votersByPoll := make(map[uuid.UUID]int, len(voters))
+	for _, voter := range voters {
+		votersByPoll[voter.MessageId] = voter.Voters
+	}
+	for i := range polls {
+		polls[i].Options = optionsByPoll[polls[i].MessageId]
+		polls[i].VoterCount = votersByPoll[polls[i].MessageId]
+	}
+	return polls, nil
+}
+
+// VotePoll replaces the votes of userId in poll. The poll row is locked, so
+// concurrent votes of a user can not add up to more options than the poll
+// allows.
+func (r *MessageRepository) VotePoll(poll *models.Poll, userId uuid.UUID, positions []int, votedAt uint64) error {
+	tx := r.DB.Begin()
+	var locked models.Poll
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("message_id = ?", poll.MessageId).First(&locked).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.Where("message_id = ? AND user_id = ?", poll.MessageId, userId).Delete(&models.PollVote{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, position := range positions {
+		vote := &models.PollVote{
+			MessageId:  poll.MessageId,
+			UserId:     userId,
+			Position:   position,
+			ChatRoomId: poll.ChatRoomId,
+			VotedAt:    votedAt,
+		}
+		if err := tx.Create(vote).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// AddReaction stores a reaction and reports whether it was not there yet.
+func (r *MessageRepository) AddReaction(reaction *models.MessageReaction) (bool, error) {
+	result := r.DB.Exec(
+		`INSERT INTO message_reactions (message_id, user_id, emoji, chat_room_id, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (message_id, user_id, emoji) DO NOTHING`,
+		reaction.MessageId, reaction.UserId, reaction.Emoji, reaction.ChatRoomId, reaction.CreatedAt,
+	)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RemoveReaction drops a reaction and reports whether there was one.
+func (r *MessageRepository) RemoveReaction(reaction *models.MessageReaction) (bool, error) {
+	result := r.DB.Where("message_id = ? AND user_id = ? AND emoji = ?", reaction.MessageId, reaction.UserId, reaction.Emoji).
+		Delete(&models.MessageReaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetReactionCounts aggregates the reactions of the given messages. Emojis of
+// a message are ordered by their first use.
+func (r *MessageRepository) GetReactionCounts(messageIds []uuid.UUID) ([]models.ReactionCount, error) {
+	var counts []models.ReactionCount
+	err := r.DB.Raw(
+		`SELECT message_id, emoji, COUNT(*) AS count FROM message_reactions
+		WHERE message_id IN (?)
+		GROUP BY message_id, emoji
+		ORDER BY message_id, MIN(created_at), emoji`,
+		messageIds,
+	).Scan(&counts).Error
+	return counts, err
+}
+
+// PinMessage pins a message unless the room already has maxPins pinned
+// messages and reports whether it was not pinned yet. Pins of a room are
+// serialized with an advisory lock so concurrent pins can not pass the limit.
+func (r *MessageRepository) PinMessage(pin *models.PinnedMessage, maxPins int) (bool, error) {
+	tx := r.DB.Begin()
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", pin.ChatRoomId.String()).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	var existing int
+	err := tx.Model(&models.PinnedMessage{}).Where("chat_room_id = ? AND message_id = ?", pin.ChatRoomId, pin.MessageId).Count(&existing).Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if existing > 0 {
+		tx.Rollback()
+		return false, nil
+	}
+	var count int
+	if err := tx.Model(&models.PinnedMessage{}).Where("chat_room_id = ?", pin.ChatRoomId).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if count >= maxPins {
+		tx.Rollback()
+		return false, fmt.Errorf("%w: the room already has %d", errors.ErrTooManyPins, count)
+	}
+	if err := tx.Create(pin).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return true, tx.Commit().Error
+}
+
+// UnpinMessage reports whether the message was pinned.
+func (r *MessageRepository) UnpinMessage(chatRoomId uuid.UUID, messageId uuid.UUID) (bool, error) {
+	result := r.DB.Where("chat_room_id = ? AND message_id = ?", chatRoomId, messageId).Delete(&models.PinnedMessage{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetPins returns the pins of a room, latest first.
+func (r *MessageRepository) GetPins(chatRoomId uuid.UUID) ([]models.PinnedMessage, error) {
+	var pins []models.PinnedMessage
+	err := r.DB.Where("chat_room_id = ?", chatRoomId).Order("pinned_at desc").Find(&pins).Error
+	return pins, err
+}
+
+func (r *MessageRepository) SaveScheduledMessage(scheduled *models.ScheduledMessage) error {
+	return r.DB.Create(scheduled).Error
+}
+
+// GetScheduledMessages returns the pending messages of a sender, the next to
+// be sent first. A nil chatRoomId returns those of every room.
+func (r *MessageRepository) GetScheduledMessages(senderId uuid.UUID, chatRoomId *uuid.UUID) ([]models.ScheduledMessage, error) {
+	var scheduled []models.ScheduledMessage
+	db := r.DB.Where("sender_id = ?", senderId)
+	if chatRoomId != nil {
+		db = db.Where("chat_room_id = ?", *chatRoomId)
+	}
+	err := db.Order("send_at, id").Find(&scheduled).Error
+	return scheduled, err
+}
+
+func (r *MessageRepository) GetScheduledMessage(id uuid.UUID, senderId uuid.UUID) (*models.ScheduledMessage, error) {
+	var scheduled models.ScheduledMessage
+	err := r.DB.Where("id = ? AND sender_id = ?", id, senderId).First(&scheduled).Error
+	return &scheduled, err
+}
+
+// UpdateScheduledMessage stores the editable fields of a scheduled message.
+// It fails with gorm.ErrRecordNotFound once the message has been sent.
+func (r *MessageRepository) UpdateScheduledMessage(scheduled *models.ScheduledMessage) error {
+	result := r.DB.Model(&models.ScheduledMessage{}).Where("id = ?", scheduled.Id).Updates(map[string]interface{}{
+		"body":    scheduled.Body,
+		"send_at": scheduled.SendAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *MessageRepository) DeleteScheduledMessage(id uuid.UUID, senderId uuid.UUID) error {
+	result := r.DB.Where("id = ? AND sender_id = ?", id, senderId).Delete(&models.ScheduledMessage{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *MessageRepository) GetDueScheduledMessages(now uint64, limit int) ([]models.ScheduledMessage, error) {
+	var scheduled []models.ScheduledMessage
+	err := r.DB.Where("send_at <= ?", now).Order("send_at, id").Limit(limit).Find(&scheduled).Error
+	return scheduled, err
 }
 
-func (r *MessageRepository) GetMessageByChatRoomId(chatRoomId uuid.UUID) ([]models.Message, error) {
+// PromoteScheduledMessage removes the scheduled message and saves message in
+// one transaction. It reports false when the scheduled message was cancelled
+// or promoted by someone else in the meantime.
+func (r *MessageRepository) PromoteScheduledMessage(scheduled *models.ScheduledMessage, message *models.Message) (bool, error) {
+	tx := r.DB.Begin()
+	result := tx.Where("id = ?", scheduled.Id).Delete(&models.ScheduledMessage{})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+	if err := tx.Create(message).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return true, tx.Commit().Error
+}
+
+// acquireLeaseScript takes the lease when it is free and extends it when the
+// caller already holds it.
+var acquireLeaseScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0`)
+
+// AcquireLeaseInRedis reports whether owner holds the named lease for the
+// next ttl. A lease whose owner stopped renewing it expires and can be taken.
+func (r *MessageRepository) AcquireLeaseInRedis(name string, owner string, ttl time.Duration) (bool, error) {
+	acquired, err := acquireLeaseScript.Run(context.Background(), r.Redis, []string{"lease:" + name}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return acquired == 1, nil
+}
+
+func (r *MessageRepository) MarkDelivered(receipt *models.MessageReceipt) error {
+	return r.DB.Exec(
+		`INSERT INTO message_receipts (message_id, user_id, chat_room_id, delivered_at, read_at)
+		VALUES (?, ?, ?, ?, 0)
+		ON CONFLICT (message_id, user_id) DO NOTHING`,
+		receipt.MessageId, receipt.UserId, receipt.ChatRoomId, receipt.DeliveredAt,
+	).Error
+}
+
+// MarkReadUpTo marks every message of the room sent by others up to the given
+// one as read by userId and returns the senders whose messages became read.
+func (r *MessageRepository) MarkReadUpTo(userId uuid.UUID, message *models.Message, readAt uint64) ([]uuid.UUID, error) {
+	tx := r.DB.Begin()
+	err := tx.Exec(
+		`INSERT INTO message_receipts (message_id, user_id, chat_room_id, delivered_at, read_at)
+		SELECT id, ?, chat_room_id, ?, ? FROM messages
+		WHERE chat_room_id = ? AND sender_id <> ? AND (created_at, id) <= (?, ?)
+		ON CONFLICT (message_id, user_id) DO UPDATE SET read_at = EXCLUDED.read_at
+		WHERE message_receipts.read_at = 0`,
+		userId, readAt, readAt, message.ChatRoomId, userId, message.CreatedAt, message.Id,
+	).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var senders []struct{ SenderId uuid.UUID }
+	err = tx.Raw(
+		`SELECT DISTINCT m.sender_id FROM messages m
+		JOIN message_receipts r ON r.message_id = m.id
+		WHERE r.user_id = ? AND r.chat_room_id = ? AND r.read_at = ?`,
+		userId, message.ChatRoomId, readAt,
+	).Scan(&senders).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	senderIds := make([]uuid.UUID, len(senders))
+	for i, sender := range senders {
+		senderIds[i] = sender.SenderId
+	}
+	return senderIds, nil
+}
+
+func (r *MessageRepository) CountUnread(userId uuid.UUID, chatRoomIds []uuid.UUID) ([]models.UnreadCount, error) {
+	var counts []models.UnreadCount
+	err := r.DB.Raw(
+		`SELECT m.chat_room_id, COUNT(*) AS count FROM messages m
+		LEFT JOIN message_receipts r ON r.message_id = m.id AND r.user_id = ?
+		WHERE m.chat_room_id IN (?) AND m.sender_id <> ? AND m.is_deleted = false
+		AND m.thread_root_id IS NULL AND (r.read_at IS NULL OR r.read_at = 0)
+		GROUP BY m.chat_room_id`,
+		userId, chatRoomIds, userId,
+	).Scan(&counts).Error
+	return counts, err
+}
+
+// GetLastMessages returns the newest message of every given room that has
+// one. Deleted messages and thread replies are not shown as the last message.
+func (r *MessageRepository) GetLastMessages(chatRoomIds []uuid.UUID) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.DB.Raw(
+		`SELECT DISTINCT ON (chat_room_id) * FROM messages
+		WHERE chat_room_id IN (?) AND is_deleted = false AND thread_root_id IS NULL
+		ORDER BY chat_room_id, created_at DESC, id DESC`,
+		chatRoomIds,
+	).Scan(&messages).Error
+	return messages, err
+}
+
+// searchHeadlineOptions wrap matched words in <mark> tags and keep snippets short.
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=\" ... \""
+
+// SearchMessages returns the messages matching the filter, newest first, with
+// their bodies highlighted. Deleted messages are never returned.
+func (r *MessageRepository) SearchMessages(filter *models.MessageSearchFilter, limit int) ([]models.MessageSearchHit, error) {
+	db := r.DB.Table("messages").
+		Select("messages.*, ts_headline('simple', messages.body, to_tsquery('simple', ?), ?) AS snippet", filter.TsQuery, searchHeadlineOptions).
+		Where("chat_room_id IN (?) AND is_deleted = false", filter.ChatRoomIds).
+		Where("body_vector @@ to_tsquery('simple', ?)", filter.TsQuery)
+	if filter.SenderId != uuid.Nil {
+		db = db.Where("sender_id = ?", filter.SenderId)
+	}
+	if filter.From != 0 {
+		db = db.Where("created_at >= ?", filter.From)
+	}
+	if filter.To != 0 {
+		db = db.Where("created_at <= ?", filter.To)
+	}
+	if filter.HasAttachment {
+		db = db.Where("with_media > 0")
+	}
+	if filter.Before != nil {
+		db = db.Where("(created_at, id) < (?, ?)", filter.Before.CreatedAt, filter.Before.Id)
+	}
+
+	var hits []models.MessageSearchHit
+	err := db.Order("created_at desc, id desc").Limit(limit).Scan(&hits).Error
+	return hits, err
+}
+
+// historyScope selects the main history of a room when threadRootId is nil
+// and the replies of that thread otherwise.
+func (r *MessageRepository) historyScope(chatRoomId uuid.UUID, threadRootId *uuid.UUID) *gorm.DB {
+	db := r.DB.Where("chat_room_id = ?", chatRoomId)
+	if threadRootId == nil {
+		return db.Where("thread_root_id IS NULL")
+	}
+	return db.Where("thread_root_id = ?", *threadRootId)
+}
+
+func (r *MessageRepository) GetLatestMessages(chatRoomId uuid.UUID, threadRootId *uuid.UUID, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.historyScope(chatRoomId, threadRootId).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+func (r *MessageRepository) GetMessagesBefore(chatRoomId uuid.UUID, threadRootId *uuid.UUID, createdAt uint64, id uuid.UUID, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.historyScope(chatRoomId, threadRootId).
+		Where("(created_at, id) < (?, ?)", createdAt, id).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+func (r *MessageRepository) GetMessagesAfter(chatRoomId uuid.UUID, threadRootId *uuid.UUID, createdAt uint64, id uuid.UUID, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.historyScope(chatRoomId, threadRootId).
+		Where("(created_at, id) > (?, ?)", createdAt, id).
+		Order("created_at asc, id asc").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+func (r *MessageRepository) GetMessagesByIds(ids []uuid.UUID) ([]models.Message, error) {
 	var messages []models.Message
-	err := r.DB.Where("chat_room_id = ?", chatRoomId).Find(&messages).Error
+	err := r.DB.Where("id IN (?)", ids).Find(&messages).Error
 	return messages, err
 }
 
+// GetThreadSummaries counts the replies under each given root. Roots without
+// replies are left out.
+func (r *MessageRepository) GetThreadSummaries(rootIds []uuid.UUID) ([]models.ThreadSummary, error) {
+	var summaries []models.ThreadSummary
+	err := r.DB.Raw(
+		`SELECT thread_root_id AS root_id, COUNT(*) AS reply_count, MAX(created_at) AS last_reply_at
+		FROM messages
+		WHERE thread_root_id IN (?) AND is_deleted = false
+		GROUP BY thread_root_id`,
+		rootIds,
+	).Scan(&summaries).Error
+	return summaries, err
+}
+
 func (r *MessageRepository) SubscribeToRedisChannel(channelName string) *redis.PubSub {
 	return r.Redis.Subscribe(context.Background(), channelName)
 }
@@ -44,14 +672,187 @@ func (r *MessageRepository) PublishToRedisChannel(channelName string, message in
 	return r.Redis.Publish(context.Background(), channelName, message).Err()
 }
 
-func (r *MessageRepository) GetUserStatusFromRedis(userId uuid.UUID) (string, error) {
-	return r.Redis.Get(context.Background(), userId.String()).Result()
+// UpdateMessageMetadata leaves deleted messages untouched, so late uploads do
+// not bring their attachments back.
+func (r *MessageRepository) UpdateMessageMetadata(messageId uuid.UUID, metadata dto.Metadata) error {
+	return r.DB.Model(&models.Message{}).Where("id = ? AND is_deleted = false", messageId).Update("metadata", metadata).Error
+}
+
+// UpdatePendingMessageMetadata leaves messages whose media already finished untouched.
+func (r *MessageRepository) UpdatePendingMessageMetadata(messageId uuid.UUID, metadata dto.Metadata) error {
+	return r.DB.Model(&models.Message{}).
+		Where("id = ? AND is_deleted = false AND metadata->>'mediaStatus' = ?", messageId, dto.MediaStatusPending).
+		Update("metadata", metadata).Error
+}
+
+const pendingMediaDeadlinesKey = "media-pending-deadlines"
+
+func messageMediaKey(messageId uuid.UUID) string {
+	return "message-media:" + messageId.String()
+}
+
+func pendingMediaKey(messageId uuid.UUID) string {
+	return "media-pending:" + messageId.String()
+}
+
+// GetMessageFilesFromRedis returns the files media-handler stored for a message.
+func (r *MessageRepository) GetMessageFilesFromRedis(messageId uuid.UUID) ([]models.MessageIdXFileId, error) {
+	values, err := r.Redis.HVals(context.Background(), messageMediaKey(messageId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	files := make([]models.MessageIdXFileId, 0, len(values))
+	for _, value := range values {
+		file := models.MessageIdXFileId{}
+		if err := json.Unmarshal([]byte(value), &file); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// SavePendingMediaInRedis registers a message waiting for its attachments
+// until deadline.
+func (r *MessageRepository) SavePendingMediaInRedis(messageId uuid.UUID, pendingMedia interface{}, deadline time.Time) error {
+	_, err := r.Redis.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.Set(context.Background(), pendingMediaKey(messageId), pendingMedia, time.Until(deadline)+time.Hour)
+		pipe.ZAdd(context.Background(), pendingMediaDeadlinesKey, &redis.Z{Score: float64(deadline.UnixMilli()), Member: messageId.String()})
+		return nil
+	})
+	return err
+}
+
+func (r *MessageRepository) GetPendingMediaFromRedis(messageId uuid.UUID) (string, error) {
+	return r.Redis.Get(context.Background(), pendingMediaKey(messageId)).Result()
+}
+
+// ClaimPendingMediaInRedis removes the pending registration. Only the caller
+// that gets true may finish the message, so concurrent instances finish it once.
+func (r *MessageRepository) ClaimPendingMediaInRedis(messageId uuid.UUID) (bool, error) {
+	var deleted *redis.IntCmd
+	_, err := r.Redis.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(context.Background(), pendingMediaKey(messageId))
+		pipe.ZRem(context.Background(), pendingMediaDeadlinesKey, messageId.String())
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted.Val() == 1, nil
+}
+
+func (r *MessageRepository) GetExpiredPendingMediaFromRedis(now time.Time) ([]string, error) {
+	return r.Redis.ZRangeByScore(context.Background(), pendingMediaDeadlinesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+}
+
+func presenceKey(userId uuid.UUID) string {
+	return "presence:" + userId.String()
 }
 
-func (r *MessageRepository) SetUserStatusInRedis(userId uuid.UUID) error {
-	return r.Redis.Set(context.Background(), userId.String(), "ONLINE", 0).Err()
+func lastSeenKey(userId uuid.UUID) string {
+	return "last-seen:" + userId.String()
 }
 
-func (r *MessageRepository) DropUserStatusInRedis(userId uuid.UUID) error {
-	return r.Redis.Del(context.Background(), userId.String()).Err()
+func (r *MessageRepository) PushToRedisQueue(queueName string, message interface{}) error {
+	return r.Redis.LPush(context.Background(), queueName, message).Err()
+}
+
+// PopFromRedisQueue blocks until a message is available. Every message is
+// handed to exactly one of the instances popping the same queue.
+func (r *MessageRepository) PopFromRedisQueue(queueName string) (string, error) {
+	result, err := r.Redis.BRPop(context.Background(), 0, queueName).Result()
+	if err != nil {
+		return "", err
+	}
+	return result[1], nil
+}
+
+func presenceMember(instanceId string, sessionId uuid.UUID) string {
+	return instanceId + "/" + sessionId.String()
+}
+
+// GetUserInstancesFromRedis returns, for every user, the distinct ids of the
+// instances holding one of the user's live sessions. Offline users get none.
+func (r *MessageRepository) GetUserInstancesFromRedis(userIds []uuid.UUID) ([][]string, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	pipe := r.Redis.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, len(userIds))
+	for i, userId := range userIds {
+		cmds[i] = pipe.ZRangeByScore(context.Background(), presenceKey(userId), &redis.ZRangeBy{Min: now, Max: "+inf"})
+	}
+	_, err := pipe.Exec(context.Background())
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	instances := make([][]string, len(userIds))
+	for i, cmd := range cmds {
+		for _, member := range cmd.Val() {
+			instanceId, _, _ := strings.Cut(member, "/")
+			if !slices.Contains(instances[i], instanceId) {
+				instances[i] = append(instances[i], instanceId)
+			}
+		}
+	}
+	return instances, nil
+}
+
+// SetUserStatusInRedis marks one session of the user online on instanceId
+// for ttl and records the last-seen timestamp. Every session is a member of
+// the user's presence set scored by its expiry, so sessions of a crashed
+// instance lapse on their own. It has to be called again before ttl elapses.
+func (r *MessageRepository) SetUserStatusInRedis(userId uuid.UUID, instanceId string, sessionId uuid.UUID, ttl time.Duration, lastSeen uint64) error {
+	expiresAt := float64(time.Now().Add(ttl).UnixMilli())
+	_, err := r.Redis.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(context.Background(), presenceKey(userId), &redis.Z{Score: expiresAt, Member: presenceMember(instanceId, sessionId)})
+		pipe.Expire(context.Background(), presenceKey(userId), ttl)
+		pipe.Set(context.Background(), lastSeenKey(userId), lastSeen, 0)
+		return nil
+	})
+	return err
+}
+
+// DropUserStatusInRedis removes one session of the user and returns how many
+// live sessions remain across all instances.
+func (r *MessageRepository) DropUserStatusInRedis(userId uuid.UUID, instanceId string, sessionId uuid.UUID, lastSeen uint64) (int64, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	var remaining *redis.IntCmd
+	_, err := r.Redis.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.ZRem(context.Background(), presenceKey(userId), presenceMember(instanceId, sessionId))
+		pipe.ZRemRangeByScore(context.Background(), presenceKey(userId), "-inf", "("+now)
+		remaining = pipe.ZCard(context.Background(), presenceKey(userId))
+		pipe.Set(context.Background(), lastSeenKey(userId), lastSeen, 0)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return remaining.Val(), nil
+}
+
+func presenceRoomsKey(userId uuid.UUID) string {
+	return "presence-rooms:" + userId.String()
+}
+
+// AddPresenceRoomInRedis remembers a room the user announced presence in,
+// shared by all of the user's sessions.
+func (r *MessageRepository) AddPresenceRoomInRedis(userId uuid.UUID, room string) error {
+	return r.Redis.SAdd(context.Background(), presenceRoomsKey(userId), room).Err()
+}
+
+func (r *MessageRepository) PopPresenceRoomsFromRedis(userId uuid.UUID) ([]string, error) {
+	var rooms *redis.StringSliceCmd
+	_, err := r.Redis.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		rooms = pipe.SMembers(context.Background(), presenceRoomsKey(userId))
+		pipe.Del(context.Background(), presenceRoomsKey(userId))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rooms.Val(), nil
 }
@@ -7,22 +7,39 @@ import (
 )
 
 type HttpServer struct {
-	messageHistoryController *controller.MessageHistoryController
-	websocketController      *controller.WebsocketController
+	messageHistoryController   *controller.MessageHistoryController
+	websocketController        *controller.WebsocketController
+	scheduledMessageController *controller.ScheduledMessageController
 }
 
-func NewHttpServer(messageHistoryController *controller.MessageHistoryController, websocketController *controller.WebsocketController) *HttpServer {
+func NewHttpServer(messageHistoryController *controller.MessageHistoryController, websocketController *controller.WebsocketController, scheduledMessageController *controller.ScheduledMessageController) *HttpServer {
 	return &HttpServer{
-		messageHistoryController: messageHistoryController,
-		websocketController:      websocketController,
+		messageHistoryController:   messageHistoryController,
+		websocketController:        websocketController,
+		scheduledMessageController: scheduledMessageController,
 	}
 }
 
 func (h *HttpServer) StartServer() {
 	http.HandleFunc("GET /{chatRoomId}/history", h.messageHistoryController.GetHistoryHandler)
+	http.HandleFunc("GET /{chatRoomId}/threads/{messageId}", h.messageHistoryController.GetThreadHandler)
+	http.HandleFunc("GET /{chatRoomId}/pins", h.messageHistoryController.GetPinsHandler)
+	http.HandleFunc("GET /unread", h.messageHistoryController.GetUnreadCountsHandler)
+	http.HandleFunc("GET /inbox", h.messageHistoryController.GetInboxHandler)
+	http.HandleFunc("GET /search", h.messageHistoryController.SearchMessagesHandler)
+	http.HandleFunc("POST /scheduled", h.scheduledMessageController.ScheduleMessageHandler)
+	http.HandleFunc("GET /scheduled", h.scheduledMessageController.ListScheduledMessagesHandler)
+	http.HandleFunc("PUT /scheduled/{scheduledMessageId}", h.scheduledMessageController.EditScheduledMessageHandler)
+	http.HandleFunc("DELETE /scheduled/{scheduledMessageId}", h.scheduledMessageController.CancelScheduledMessageHandler)
 	http.HandleFunc("/websocket/channel", h.websocketController.SendMessageInChannelHandler)
 	http.HandleFunc("/websocket/chat", h.websocketController.SendMessageInChatRoomHandler)
 	go h.websocketController.StartBroadcastingToChatRooms()
 	go h.websocketController.StartBroadcastingToChannels()
 	go h.websocketController.StartListeningFileChannel()
+	go h.websocketController.StartListeningChatDeletedChannel()
+	go h.websocketController.StartSweepingPendingMedia()
+	go h.websocketController.StartListeningInstanceChannel()
+	go h.websocketController.StartBroadcastingEphemeralEvents()
+	go h.websocketController.StartReapingExpiredMessages()
+	go h.scheduledMessageController.StartSendingScheduledMessages()
 }
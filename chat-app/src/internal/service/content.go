@@ -0,0 +1,255 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"example.com/chat-app/src/internal/dto"
+	"example.com/chat-app/src/internal/errors"
+	"example.com/chat-app/src/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+	MaxPollQuestionLength = 300
+	MaxPollOptionLength   = 100
+	// MaxContentFieldLength bounds names, addresses, phone numbers and the
+	// other short fields of structured payloads.
+	MaxContentFieldLength = 200
+)
+
+// checkContent validates the structured payload of a new message. Every
+// content type but text carries exactly the payload of its type and no
+// attachments.
+func checkContent(message *models.Message) error {
+	content := message.Content
+	payloads := 0
+	for _, present := range []bool{content.Location != nil, content.Contact != nil, content.Sticker != nil, message.Poll != nil} {
+		if present {
+			payloads++
+		}
+	}
+	if message.ContentType == dto.ContentTypeText {
+		if payloads != 0 {
+			return fmt.Errorf("%w: text message can not carry a payload", errors.ErrInvalidContent)
+		}
+		return nil
+	}
+	if payloads != 1 {
+		return fmt.Errorf("%w: %s message needs exactly its own payload", errors.ErrInvalidContent, message.ContentType)
+	}
+	if message.WithMedia != 0 {
+		return fmt.Errorf("%w: %s message can not have attachments", errors.ErrInvalidContent, message.ContentType)
+	}
+
+	switch message.ContentType {
+	case dto.ContentTypeLocation:
+		return checkLocation(content.Location)
+	case dto.ContentTypeContact:
+		return checkContact(content.Contact)
+	case dto.ContentTypeSticker:
+		return checkSticker(content.Sticker)
+	case dto.ContentTypePoll:
+		return checkPoll(message.Poll)
+	default:
+		return fmt.Errorf("%w: unknown content type %q", errors.ErrInvalidContent, message.ContentType)
+	}
+}
+
+func checkLocation(location *dto.Location) error {
+	if location == nil {
+		return fmt.Errorf("%w: location is missing", errors.ErrInvalidContent)
+	}
+	if math.IsNaN(location.Latitude) || location.Latitude < -90 || location.Latitude > 90 {
+		return fmt.Errorf("%w: latitude must be between -90 and 90", errors.ErrInvalidContent)
+	}
+	if math.IsNaN(location.Longitude) || location.Longitude < -180 || location.Longitude > 180 {
+		return fmt.Errorf("%w: longitude must be between -180 and 180", errors.ErrInvalidContent)
+	}
+	return checkFieldLengths(location.Name, location.Address)
+}
+
+func checkContact(contact *dto.Contact) error {
+	if contact == nil {
+		return fmt.Errorf("%w: contact is missing", errors.ErrInvalidContent)
+	}
+	if strings.TrimSpace(contact.Name) == "" {
+		return fmt.Errorf("%w: contact name is empty", errors.ErrInvalidContent)
+	}
+	if contact.Phone == "" && contact.Email == "" && contact.UserId == "" {
+		return fmt.Errorf("%w: contact needs a phone, an email or a user", errors.ErrInvalidContent)
+	}
+	if contact.Email != "" && !strings.Contains(contact.Email, "@") {
+		return fmt.Errorf("%w: %q is not an email", errors.ErrInvalidContent, contact.Email)
+	}
+	if contact.UserId != "" {
+		if _, err := uuid.Parse(contact.UserId); err != nil {
+			return fmt.Errorf("%w: %v", errors.ErrInvalidContent, err)
+		}
+	}
+	return checkFieldLengths(contact.Name, contact.Phone, contact.Email)
+}
+
+func checkSticker(sticker *dto.Sticker) error {
+	if sticker == nil {
+		return fmt.Errorf("%w: sticker is missing", errors.ErrInvalidContent)
+	}
+	if sticker.PackId == "" || sticker.StickerId == "" {
+		return fmt.Errorf("%w: sticker needs a pack and a sticker id", errors.ErrInvalidContent)
+	}
+	return checkFieldLengths(sticker.PackId, sticker.StickerId)
+}
+
+func checkPoll(poll *models.Poll) error {
+	if poll == nil {
+		return fmt.Errorf("%w: poll is missing", errors.ErrInvalidContent)
+	}
+	question := strings.TrimSpace(poll.Question)
+	if question == "" || utf8.RuneCountInString(question) > MaxPollQuestionLength {
+		return fmt.Errorf("%w: poll question must have 1 to %d characters", errors.ErrInvalidContent, MaxPollQuestionLength)
+	}
+	if len(poll.Options) < MinPollOptions || len(poll.Options) > MaxPollOptions {
+		return fmt.Errorf("%w: poll must have %d to %d options", errors.ErrInvalidContent, MinPollOptions, MaxPollOptions)
+	}
+	seen := make(map[string]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		text := strings.TrimSpace(option.Text)
+		if text == "" || utf8.RuneCountInString(text) > MaxPollOptionLength {
+			return fmt.Errorf("%w: poll option must have 1 to %d characters", errors.ErrInvalidContent, MaxPollOptionLength)
+		}
+		if seen[text] {
+			return fmt.Errorf("%w: poll option %q is repeated", errors.ErrInvalidContent, text)
+		}
+		seen[text] = true
+	}
+	if poll.ClosesAt != 0 && poll.ClosesAt <= uint64(time.Now().UnixMilli()) {
+		return fmt.Errorf("%w: poll close time must be in the future", errors.ErrInvalidContent)
+	}
+	return nil
+}
+
+func checkFieldLengths(fields ...string) error {
+	for _, field := range fields {
+		if utf8.RuneCountInString(field) > MaxContentFieldLength {
+			return fmt.Errorf("%w: fields can not be longer than %d characters", errors.ErrInvalidContent, MaxContentFieldLength)
+		}
+	}
+	return nil
+}
+
+// Vote replaces the votes of a room member in a poll until it closes. The
+// poll message is published with its new counts.
+func (m *MessageService) Vote(userId uuid.UUID, roomType models.RoomType, voteReq *dto.VoteRequest, accessToken string, refreshToken string) (*models.Message, error) {
+	chatRoomId, err := uuid.Parse(voteReq.ChatRoomId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	messageId, err := uuid.Parse(voteReq.MessageId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	message, err := m.getRoomMessage(chatRoomId, messageId)
+	if err != nil {
+		return nil, err
+	}
+	if message.ContentType != dto.ContentTypePoll {
+		return nil, fmt.Errorf("%w: %v is not a poll", errors.ErrInvalidVote, messageId)
+	}
+
+	isParticipant, err := m.isRoomParticipant(roomType, chatRoomId, accessToken, refreshToken, userId)
+	if err != nil || !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", userId, chatRoomId), "error", err)
+		return nil, fmt.Errorf("%w: %v is not a participant of %v", errors.ErrPermissionDenied, userId, chatRoomId)
+	}
+
+	poll, err := m.getPoll(messageId)
+	if err != nil {
+		return nil, err
+	}
+	now := uint64(time.Now().UnixMilli())
+	if poll.IsClosed(now) {
+		return nil, fmt.Errorf("%w: poll %v is closed", errors.ErrInvalidVote, messageId)
+	}
+	positions := slices.Clone(voteReq.Options)
+	slices.Sort(positions)
+	positions = slices.Compact(positions)
+	if len(positions) > 1 && !poll.MultipleChoice {
+		return nil, fmt.Errorf("%w: poll %v allows one option", errors.ErrInvalidVote, messageId)
+	}
+	for _, position := range positions {
+		if position < 0 || position >= len(poll.Options) {
+			return nil, fmt.Errorf("%w: poll %v has no option %d", errors.ErrInvalidVote, messageId, position)
+		}
+	}
+
+	err = m.messageRepository.VotePoll(poll, userId, positions, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	message.Poll, err = m.getPoll(messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	messageWithTokens := models.MessageWithTokens{
+		Type:         dto.MessageTypePoll,
+		Message:      *message,
+		ActorId:      userId,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(roomType), bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	slog.Debug(fmt.Sprintf("Vote of %v in poll %v published", userId, messageId))
+	return message, nil
+}
+
+func (m *MessageService) getPoll(messageId uuid.UUID) (*models.Poll, error) {
+	polls, err := m.messageRepository.GetPolls([]uuid.UUID{messageId})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if len(polls) == 0 {
+		return nil, fmt.Errorf("%w: poll %v", errors.ErrMessageNotFound, messageId)
+	}
+	return &polls[0], nil
+}
+
+// fillPolls attaches the polls of the poll messages that are not deleted.
+func (m *MessageHistoryService) fillPolls(messages []models.Message) error {
+	var messageIds []uuid.UUID
+	for _, message := range messages {
+		if !message.IsDeleted && message.ContentType == dto.ContentTypePoll {
+			messageIds = append(messageIds, message.Id)
+		}
+	}
+	if len(messageIds) == 0 {
+		return nil
+	}
+	polls, err := m.messageRepository.GetPolls(messageIds)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	pollByMessage := make(map[uuid.UUID]*models.Poll, len(polls))
+	for i := range polls {
+		pollByMessage[polls[i].MessageId] = &polls[i]
+	}
+	for i := range messages {
+		messages[i].Poll = pollByMessage[messages[i].Id]
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	e "errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/chat-app/src/internal/dto"
+	"example.com/chat-app/src/internal/errors"
+	"example.com/chat-app/src/internal/models"
+	"github.com/google/uuid"
+)
+
+func pollOptions(texts ...string) []models.PollOption {
+	options := make([]models.PollOption, len(texts))
+	for i, text := range texts {
+		options[i] = models.PollOption{Position: i, Text: text}
+	}
+	return options
+}
+
+func TestCheckContent(t *testing.T) {
+	validPoll := &models.Poll{Question: "Lunch?", Options: pollOptions("Pizza", "Sushi")}
+	tests := []struct {
+		name    string
+		message models.Message
+		wantErr bool
+	}{
+		{name: "text", message: models.Message{ContentType: dto.ContentTypeText}},
+		{name: "text with attachments", message: models.Message{ContentType: dto.ContentTypeText, WithMedia: 2}},
+		{
+			name:    "text with payload",
+			message: models.Message{ContentType: dto.ContentTypeText, Content: dto.Content{Sticker: &dto.Sticker{PackId: "p", StickerId: "s"}}},
+			wantErr: true,
+		},
+		{
+			name:    "location",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{Location: &dto.Location{Latitude: 52.52, Longitude: 13.40, Name: "Berlin"}}},
+		},
+		{
+			name:    "location on the bounds",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{Location: &dto.Location{Latitude: -90, Longitude: 180}}},
+		},
+		{
+			name:    "latitude out of range",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{Location: &dto.Location{Latitude: 90.1}}},
+			wantErr: true,
+		},
+		{
+			name:    "longitude out of range",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{Location: &dto.Location{Longitude: -180.1}}},
+			wantErr: true,
+		},
+		{
+			name:    "latitude not a number",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{Location: &dto.Location{Latitude: math.NaN()}}},
+			wantErr: true,
+		},
+		{
+			name:    "location name too long",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{Location: &dto.Location{Name: strings.Repeat("a", MaxContentFieldLength+1)}}},
+			wantErr: true,
+		},
+		{
+			name:    "location with attachments",
+			message: models.Message{ContentType: dto.ContentTypeLocation, WithMedia: 1, Content: dto.Content{Location: &dto.Location{}}},
+			wantErr: true,
+		},
+		{
+			name:    "location without payload",
+			message: models.Message{ContentType: dto.ContentTypeLocation},
+			wantErr: true,
+		},
+		{
+			name:    "location with the payload of a sticker",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{Sticker: &dto.Sticker{PackId: "p", StickerId: "s"}}},
+			wantErr: true,
+		},
+		{
+			name: "two payloads",
+			message: models.Message{ContentType: dto.ContentTypeLocation, Content: dto.Content{
+				Location: &dto.Location{},
+				Sticker:  &dto.Sticker{PackId: "p", StickerId: "s"},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "contact with a phone",
+			message: models.Message{ContentType: dto.ContentTypeContact, Content: dto.Content{Contact: &dto.Contact{Name: "Ada", Phone: "+4930123456"}}},
+		},
+		{
+			name:    "contact with a user",
+			message: models.Message{ContentType: dto.ContentTypeContact, Content: dto.Content{Contact: &dto.Contact{Name: "Ada", UserId: uuid.NewString()}}},
+		},
+		{
+			name:    "contact without name",
+			message: models.Message{ContentType: dto.ContentTypeContact, Content: dto.Content{Contact: &dto.Contact{Name: " ", Phone: "+4930123456"}}},
+			wantErr: true,
+		},
+		{
+			name:    "contact without a way to reach",
+			message: models.Message{ContentType: dto.ContentTypeContact, Content: dto.Content{Contact: &dto.Contact{Name: "Ada"}}},
+			wantErr: true,
+		},
+		{
+			name:    "contact with a bad email",
+			message: models.Message{ContentType: dto.ContentTypeContact, Content: dto.Content{Contact: &dto.Contact{Name: "Ada", Email: "ada.example.com"}}},
+			wantErr: true,
+		},
+		{
+			name:    "contact with a bad user",
+			message: models.Message{ContentType: dto.ContentTypeContact, Content: dto.Content{Contact: &dto.Contact{Name: "Ada", UserId: "ada"}}},
+			wantErr: true,
+		},
+		{
+			name:    "sticker",
+			message: models.Message{ContentType: dto.ContentTypeSticker, Content: dto.Content{Sticker: &dto.Sticker{PackId: "cats", StickerId: "wave"}}},
+		},
+		{
+			name:    "sticker without id",
+			message: models.Message{ContentType: dto.ContentTypeSticker, Content: dto.Content{Sticker: &dto.Sticker{PackId: "cats"}}},
+			wantErr: true,
+		},
+		{name: "poll", message: models.Message{ContentType: dto.ContentTypePoll, Poll: validPoll}},
+		{
+			name:    "poll with attachments",
+			message: models.Message{ContentType: dto.ContentTypePoll, WithMedia: 1, Poll: validPoll},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			message: models.Message{ContentType: "audio", Content: dto.Content{Location: &dto.Location{}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkContent(&tt.message)
+			if tt.wantErr {
+				if !e.Is(err, errors.ErrInvalidContent) {
+					t.Fatalf("checkContent() = %v, want %v", err, errors.ErrInvalidContent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkContent() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestCheckPoll(t *testing.T) {
+	tooManyOptions := make([]string, MaxPollOptions+1)
+	for i := range tooManyOptions {
+		tooManyOptions[i] = strings.Repeat("o", i+1)
+	}
+	tests := []struct {
+		name    string
+		poll    *models.Poll
+		wantErr bool
+	}{
+		{name: "missing", poll: nil, wantErr: true},
+		{name: "two options", poll: &models.Poll{Question: "Lunch?", Options: pollOptions("Pizza", "Sushi")}},
+		{name: "most options", poll: &models.Poll{Question: "Lunch?", Options: pollOptions(tooManyOptions[:MaxPollOptions]...)}},
+		{name: "longest question", poll: &models.Poll{Question: strings.Repeat("ö", MaxPollQuestionLength), Options: pollOptions("a", "b")}},
+		{name: "closes in the future", poll: &models.Poll{Question: "Lunch?", Options: pollOptions("a", "b"), ClosesAt: uint64(time.Now().Add(time.Hour).UnixMilli())}},
+		{name: "blank question", poll: &models.Poll{Question: "  ", Options: pollOptions("a", "b")}, wantErr: true},
+		{name: "question too long", poll: &models.Poll{Question: strings.Repeat("ö", MaxPollQuestionLength+1), Options: pollOptions("a", "b")}, wantErr: true},
+		{name: "one option", poll: &models.Poll{Question: "Lunch?", Options: pollOptions("Pizza")}, wantErr: true},
+		{name: "too many options", poll: &models.Poll{Question: "Lunch?", Options: pollOptions(tooManyOptions...)}, wantErr: true},
+		{name: "blank option", poll: &models.Poll{Question: "Lunch?", Options: pollOptions("Pizza", " ")}, wantErr: true},
+		{name: "option too long", poll: &models.Poll{Question: "Lunch?", Options: pollOptions("Pizza", strings.Repeat("a", MaxPollOptionLength+1))}, wantErr: true},
+		{name: "repeated option", poll: &models.Poll{Question: "Lunch?", Options: pollOptions("Pizza", " Pizza ")}, wantErr: true},
+		{name: "closed", poll: &models.Poll{Question: "Lunch?", Options: pollOptions("a", "b"), ClosesAt: uint64(time.Now().Add(-time.Hour).UnixMilli())}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPoll(tt.poll)
+			if tt.wantErr {
+				if !e.Is(err, errors.ErrInvalidContent) {
+					t.Fatalf("checkPoll() = %v, want %v", err, errors.ErrInvalidContent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkPoll() = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"example.com/chat-app/src/internal/dto"
+	"example.com/chat-app/src/internal/models"
+)
+
+const (
+	// ExpirySweepInterval is how often expired messages are reaped.
+	ExpirySweepInterval = 5 * time.Second
+	ExpiryLeaseTTL      = 3 * ExpirySweepInterval
+	ExpiryBatchSize     = 100
+	expiryLeaseName     = "message-reaper"
+)
+
+// expiryOf returns when a message created at createdAt disappears in a room
+// with a message TTL of ttl seconds, or 0 when the room keeps its messages.
+func expiryOf(createdAt uint64, ttl uint64) uint64 {
+	if ttl == 0 {
+		return 0
+	}
+	return createdAt + ttl*1000
+}
+
+// ReapExpiredMessages deletes disappearing messages once they expired. Like
+// SendScheduledMessages it runs on every instance, but only the holder of the
+// Redis lease reaps.
+func (m *MessageService) ReapExpiredMessages() {
+	ticker := time.NewTicker(ExpirySweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		leased, err := m.messageRepository.AcquireLeaseInRedis(expiryLeaseName, m.instanceId, ExpiryLeaseTTL)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while acquiring message reaper lease: %v", err.Error()))
+			continue
+		}
+		if !leased {
+			continue
+		}
+		expired, err := m.messageRepository.GetExpiredMessages(uint64(time.Now().UnixMilli()), ExpiryBatchSize)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while getting expired messages: %v", err.Error()))
+			continue
+		}
+		for i := range expired {
+			m.reapMessage(&expired[i])
+		}
+	}
+}
+
+// reapMessage deletes an expired message, asks media-handler to drop its
+// attachments and tells the connected participants to drop it as well.
+func (m *MessageService) reapMessage(message *models.Message) {
+	deleted, err := m.messageRepository.DeleteExpiredMessage(message.Id)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while deleting expired message %v: %v", message.Id, err.Error()))
+		return
+	}
+	if !deleted {
+		return
+	}
+	slog.Debug(fmt.Sprintf("Expired message %v deleted", message.Id))
+
+	bytes, err := json.Marshal(models.MessageExpiredEvent{
+		MessageId:  message.Id,
+		ChatRoomId: message.ChatRoomId,
+		ExpiredAt:  uint64(time.Now().UnixMilli()),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+	} else if err = m.messageRepository.PublishToRedisChannel("message-expired-channel", bytes); err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while publishing expired message %v: %v", message.Id, err.Error()))
+	}
+	m.announceExpiry(message)
+}
+
+// announceExpiry publishes the deletion of an expired message to its chat.
+// The event carries no tokens; the chat is read with the service token.
+// Clients that miss it drop the message with the next page of history.
+func (m *MessageService) announceExpiry(message *models.Message) {
+	expired := *message
+	expired.Body = ""
+	expired.Content = dto.Content{}
+	expired.Metadata = dto.Metadata{}
+	expired.IsDeleted = true
+	bytes, err := json.Marshal(models.MessageWithTokens{
+		Type:    dto.MessageTypeDelete,
+		Message: expired,
+		ActorId: message.SenderId,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	err = m.messageRepository.PushToRedisQueue(m.RedisQueueForChatRoomMessagesName, bytes)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while publishing expiry of message %v: %v", message.Id, err.Error()))
+	}
+}
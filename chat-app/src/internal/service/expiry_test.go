@@ -0,0 +1,24 @@
+package service
+
+import "testing"
+
+func TestExpiryOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt uint64
+		ttl       uint64
+		want      uint64
+	}{
+		{name: "room keeps messages", createdAt: 1700000000000, ttl: 0, want: 0},
+		{name: "one second", createdAt: 1700000000000, ttl: 1, want: 1700000001000},
+		{name: "one day", createdAt: 1700000000000, ttl: 86400, want: 1700086400000},
+		{name: "created at zero", createdAt: 0, ttl: 30, want: 30000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expiryOf(tt.createdAt, tt.ttl); got != tt.want {
+				t.Fatalf("expiryOf(%d, %d) = %d, want %d", tt.createdAt, tt.ttl, got, tt.want)
+			}
+		})
+	}
+}
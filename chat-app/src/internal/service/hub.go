@@ -0,0 +1,178 @@
+package service
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"example.com/chat-app/src/internal/dto"
+	"example.com/chat-app/src/internal/errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// WriteWait is the time allowed to write one frame to a peer.
+	WriteWait = 10 * time.Second
+	// PongWait is the time allowed to read the next pong from a peer.
+	PongWait = 60 * time.Second
+	// PingInterval must be shorter than PongWait.
+	PingInterval = PongWait * 9 / 10
+	// SendQueueSize bounds the frames waiting for a slow peer. A session whose
+	// queue is full is evicted instead of blocking the fan-out.
+	SendQueueSize = 256
+)
+
+// Hub is the registry of the websocket sessions held by this instance.
+type Hub struct {
+	mutex    sync.RWMutex
+	sessions map[uuid.UUID]map[uuid.UUID]*wsSession
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		sessions: make(map[uuid.UUID]map[uuid.UUID]*wsSession),
+	}
+}
+
+func (h *Hub) Add(session *wsSession) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	sessions, ok := h.sessions[session.userId]
+	if !ok {
+		sessions = make(map[uuid.UUID]*wsSession)
+		h.sessions[session.userId] = sessions
+	}
+	sessions[session.id] = session
+}
+
+func (h *Hub) Remove(session *wsSession) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	sessions, ok := h.sessions[session.userId]
+	if !ok {
+		return
+	}
+	delete(sessions, session.id)
+	if len(sessions) == 0 {
+		delete(h.sessions, session.userId)
+	}
+}
+
+// SessionsOf returns a snapshot of the user's sessions that is safe to use
+// without holding the lock.
+func (h *Hub) SessionsOf(userId uuid.UUID) []*wsSession {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	sessions := make([]*wsSession, 0, len(h.sessions[userId]))
+	for _, session := range h.sessions[userId] {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+func (h *Hub) IsConnected(userId uuid.UUID) bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.sessions[userId]) > 0
+}
+
+type outgoingFrame struct {
+	envelope *dto.Envelope
+	// onWritten runs on the writer goroutine once the frame reached the socket.
+	onWritten func()
+}
+
+// wsSession is one device connection of a user. Only its writer goroutine
+// writes to the connection; everyone else enqueues frames.
+type wsSession struct {
+	id         uuid.UUID
+	userId     uuid.UUID
+	conn       *websocket.Conn
+	send       chan outgoingFrame
+	done       chan struct{}
+	writerDone chan struct{}
+	closeOnce  sync.Once
+}
+
+func newWsSession(userId uuid.UUID, conn *websocket.Conn) *wsSession {
+	return &wsSession{
+		id:         uuid.New(),
+		userId:     userId,
+		conn:       conn,
+		send:       make(chan outgoingFrame, SendQueueSize),
+		done:       make(chan struct{}),
+		writerDone: make(chan struct{}),
+	}
+}
+
+func (s *wsSession) writeEnvelope(envelope *dto.Envelope) error {
+	return s.enqueue(envelope, nil)
+}
+
+// enqueue never blocks. A session with a full queue is closed.
+func (s *wsSession) enqueue(envelope *dto.Envelope, onWritten func()) error {
+	select {
+	case <-s.done:
+		return errors.ErrSessionClosed
+	default:
+	}
+	select {
+	case s.send <- outgoingFrame{envelope: envelope, onWritten: onWritten}:
+		return nil
+	default:
+		slog.Error("Evicting slow websocket session", "session", s.id, "user", s.userId)
+		s.close()
+		return errors.ErrSlowConsumer
+	}
+}
+
+// close stops the writer and the connection, which also ends the read loop.
+func (s *wsSession) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.conn.Close()
+	})
+}
+
+// writePump writes queued frames and keepalive pings until the session is
+// closed or a write fails.
+func (s *wsSession) writePump() {
+	ticker := time.NewTicker(PingInterval)
+	defer func() {
+		ticker.Stop()
+		s.close()
+		close(s.writerDone)
+	}()
+	for {
+		select {
+		case <-s.done:
+			return
+		case frame := <-s.send:
+			s.conn.SetWriteDeadline(time.Now().Add(WriteWait))
+			err := s.conn.WriteJSON(frame.envelope)
+			if err != nil {
+				slog.Error("Error has occured while writing to websocket", "session", s.id, "user", s.userId, "error", err.Error())
+				return
+			}
+			if frame.onWritten != nil {
+				frame.onWritten()
+			}
+		case <-ticker.C:
+			s.conn.SetWriteDeadline(time.Now().Add(WriteWait))
+			err := s.conn.WriteMessage(websocket.PingMessage, nil)
+			if err != nil {
+				slog.Error("Error has occured while pinging websocket", "session", s.id, "user", s.userId, "error", err.Error())
+				return
+			}
+		}
+	}
+}
+
+// startKeepalive makes reads fail once the peer stops answering pings.
+func (s *wsSession) startKeepalive() {
+	s.conn.SetReadDeadline(time.Now().Add(PongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(PongWait))
+	})
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	e "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"example.com/chat-app/src/internal/dto"
+	"example.com/chat-app/src/internal/errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// newConnPair returns the server side of a websocket connection and the
+// client talking to it.
+func newConnPair(t *testing.T) (*websocket.Conn, *websocket.Conn) {
+	t.Helper()
+	accepted := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		accepted <- conn
+	}))
+	t.Cleanup(server.Close)
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	conn := <-accepted
+	t.Cleanup(func() { conn.Close() })
+	return conn, client
+}
+
+func TestHubConcurrentSessions(t *testing.T) {
+	const users, sessionsPerUser = 8, 16
+	hub := NewHub()
+	userIds := make([]uuid.UUID, users)
+	for i := range userIds {
+		userIds[i] = uuid.New()
+	}
+
+	var wg sync.WaitGroup
+	for _, userId := range userIds {
+		for i := 0; i < sessionsPerUser; i++ {
+			wg.Add(1)
+			go func(userId uuid.UUID, keep bool) {
+				defer wg.Done()
+				session := newWsSession(userId, nil)
+				hub.Add(session)
+				hub.IsConnected(userId)
+				hub.SessionsOf(userId)
+				if !keep {
+					hub.Remove(session)
+				}
+			}(userId, i%2 == 0)
+		}
+	}
+	wg.Wait()
+
+	for _, userId := range userIds {
+		if !hub.IsConnected(userId) {
+			t.Fatalf("user %v is not connected", userId)
+		}
+		if got := len(hub.SessionsOf(userId)); got != sessionsPerUser/2 {
+			t.Fatalf("user %v has %d sessions, want %d", userId, got, sessionsPerUser/2)
+		}
+	}
+}
+
+func TestHubRemoveLastSession(t *testing.T) {
+	hub := NewHub()
+	userId := uuid.New()
+	first, second := newWsSession(userId, nil), newWsSession(userId, nil)
+	hub.Add(first)
+	hub.Add(second)
+
+	hub.Remove(first)
+	if !hub.IsConnected(userId) {
+		t.Fatal("user disconnected while a session is left")
+	}
+	hub.Remove(second)
+	if hub.IsConnected(userId) {
+		t.Fatal("user connected without sessions")
+	}
+	if got := hub.SessionsOf(userId); len(got) != 0 {
+		t.Fatalf("SessionsOf() = %v, want none", got)
+	}
+	// Removing an unknown session is a no-op.
+	hub.Remove(first)
+}
+
+func TestEnqueueEvictsSlowConsumer(t *testing.T) {
+	conn, _ := newConnPair(t)
+	session := newWsSession(uuid.New(), conn)
+
+	// Without a writer nothing drains the queue.
+	for i := 0; i < SendQueueSize; i++ {
+		if err := session.writeEnvelope(&dto.Envelope{Type: "ping"}); err != nil {
+			t.Fatalf("enqueue %d: %v", i, err)
+		}
+	}
+	if err := session.writeEnvelope(&dto.Envelope{Type: "ping"}); !e.Is(err, errors.ErrSlowConsumer) {
+		t.Fatalf("enqueue on a full queue = %v, want %v", err, errors.ErrSlowConsumer)
+	}
+	select {
+	case <-session.done:
+	default:
+		t.Fatal("slow session is not closed")
+	}
+	if err := session.writeEnvelope(&dto.Envelope{Type: "ping"}); !e.Is(err, errors.ErrSessionClosed) {
+		t.Fatalf("enqueue on a closed session = %v, want %v", err, errors.ErrSessionClosed)
+	}
+}
+
+func TestEnqueueConcurrentWriters(t *testing.T) {
+	// The frames fit the queue, so no writer is evicted however slowly the
+	// pump drains it.
+	const writers, framesPerWriter = 8, SendQueueSize / 8
+	conn, client := newConnPair(t)
+	session := newWsSession(uuid.New(), conn)
+	go session.writePump()
+
+	received := make(chan int, 1)
+	go func() {
+		count := 0
+		for count < writers*framesPerWriter {
+			var envelope dto.Envelope
+			if err := client.ReadJSON(&envelope); err != nil {
+				break
+			}
+			count++
+		}
+		received <- count
+	}()
+
+	var written atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < framesPerWriter; j++ {
+				err := session.enqueue(&dto.Envelope{Type: "message"}, func() { written.Add(1) })
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := <-received; got != writers*framesPerWriter {
+		t.Fatalf("client received %d frames, want %d", got, writers*framesPerWriter)
+	}
+	session.close()
+	<-session.writerDone
+	if got := written.Load(); got != writers*framesPerWriter {
+		t.Fatalf("onWritten ran %d times, want %d", got, writers*framesPerWriter)
+	}
+	if err := session.writeEnvelope(&dto.Envelope{Type: "message"}); !e.Is(err, errors.ErrSessionClosed) {
+		t.Fatalf("enqueue after close = %v, want %v", err, errors.ErrSessionClosed)
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"example.com/chat-app/src/internal/dto"
+	"example.com/chat-app/src/internal/errors"
+	"example.com/chat-app/src/internal/models"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ScheduleSweepInterval is how often due scheduled messages are sent.
+	ScheduleSweepInterval = 5 * time.Second
+	// ScheduleLeaseTTL outlives a few sweeps so a crashed instance hands the
+	// sending over to another one shortly after.
+	ScheduleLeaseTTL  = 3 * ScheduleSweepInterval
+	ScheduleBatchSize = 100
+	MaxScheduleAhead  = 365 * 24 * time.Hour
+	scheduleLeaseName = "scheduled-messages"
+)
+
+// ScheduleMessage queues a message of userId until its send time. The room
+// type is resolved by the caller, who also checked that the user is a member.
+func (m *MessageService) ScheduleMessage(userId uuid.UUID, roomType models.RoomType, scheduleReq *dto.ScheduleMessageRequest, accessToken string, refreshToken string) (*models.ScheduledMessage, error) {
+	chatRoomId, err := uuid.Parse(scheduleReq.ChatRoomId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	scheduled := &models.ScheduledMessage{
+		Id:         uuid.New(),
+		SenderId:   userId,
+		ChatRoomId: chatRoomId,
+		RoomType:   roomType,
+		Body:       scheduleReq.Body,
+		SendAt:     scheduleReq.SendAt,
+		CreatedAt:  uint64(time.Now().UnixMilli()),
+	}
+	if scheduleReq.ReplyToId != "" {
+		replyToId, err := uuid.Parse(scheduleReq.ReplyToId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		scheduled.ReplyToId = &replyToId
+	}
+	if scheduleReq.ThreadRootId != "" {
+		threadRootId, err := uuid.Parse(scheduleReq.ThreadRootId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		scheduled.ThreadRootId = &threadRootId
+	}
+	err = checkSchedule(scheduled)
+	if err != nil {
+		return nil, err
+	}
+	err = m.checkReply(roomType, scheduled.ToMessage())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.checkPost(roomType, chatRoomId, userId, accessToken, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.messageRepository.SaveScheduledMessage(scheduled)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	slog.Debug(fmt.Sprintf("Message %v scheduled for %v", scheduled.Id, scheduled.SendAt))
+	return scheduled, nil
+}
+
+// ListScheduledMessages returns the messages userId has scheduled, optionally
+// limited to one room.
+func (m *MessageService) ListScheduledMessages(userId uuid.UUID, chatRoomId *uuid.UUID) ([]models.ScheduledMessage, error) {
+	scheduled, err := m.messageRepository.GetScheduledMessages(userId, chatRoomId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	return scheduled, nil
+}
+
+// EditScheduledMessage changes the body or the send time of a message that
+// has not been sent yet.
+func (m *MessageService) EditScheduledMessage(userId uuid.UUID, scheduledId uuid.UUID, editReq *dto.EditScheduledMessageRequest) (*models.ScheduledMessage, error) {
+	scheduled, err := m.messageRepository.GetScheduledMessage(scheduledId, userId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, scheduledId)
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if editReq.Body != "" {
+		scheduled.Body = editReq.Body
+	}
+	if editReq.SendAt != 0 {
+		scheduled.SendAt = editReq.SendAt
+	}
+	err = checkSchedule(scheduled)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.messageRepository.UpdateScheduledMessage(scheduled)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, scheduledId)
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	return scheduled, nil
+}
+
+// CancelScheduledMessage drops a message that has not been sent yet.
+func (m *MessageService) CancelScheduledMessage(userId uuid.UUID, scheduledId uuid.UUID) error {
+	err := m.messageRepository.DeleteScheduledMessage(scheduledId, userId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: %v", errors.ErrMessageNotFound, scheduledId)
+		}
+		return fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	return nil
+}
+
+func checkSchedule(scheduled *models.ScheduledMessage) error {
+	if scheduled.Body == "" {
+		return fmt.Errorf("%w: message body is empty", errors.ErrInvalidSchedule)
+	}
+	now := time.Now()
+	if scheduled.SendAt <= uint64(now.UnixMilli()) {
+		return fmt.Errorf("%w: send time must be in the future", errors.ErrInvalidSchedule)
+	}
+	if scheduled.SendAt > uint64(now.Add(MaxScheduleAhead).UnixMilli()) {
+		return fmt.Errorf("%w: send time must be within %v", errors.ErrInvalidSchedule, MaxScheduleAhead)
+	}
+	return nil
+}
+
+// SendScheduledMessages sends due scheduled messages. Every instance runs it,
+// but only the holder of the Redis lease sends, and every message is claimed
+// in the same transaction that saves it, so none is sent twice.
+func (m *MessageService) SendScheduledMessages() {
+	ticker := time.NewTicker(ScheduleSweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		leased, err := m.messageRepository.AcquireLeaseInRedis(scheduleLeaseName, m.instanceId, ScheduleLeaseTTL)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while acquiring scheduled messages lease: %v", err.Error()))
+			continue
+		}
+		if !leased {
+			continue
+		}
+		due, err := m.messageRepository.GetDueScheduledMessages(uint64(time.Now().UnixMilli()), ScheduleBatchSize)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while getting due scheduled messages: %v", err.Error()))
+			continue
+		}
+		for i := range due {
+			m.sendScheduledMessage(&due[i])
+		}
+	}
+}
+
+// sendScheduledMessage turns a scheduled message into a normal one and
+// publishes it for fan-out.
+func (m *MessageService) sendScheduledMessage(scheduled *models.ScheduledMessage) {
+	// The sender may have left the room or lost the right to post since.
+	allowed, ttl, err := m.canPostAsService(scheduled.RoomType, scheduled.ChatRoomId, scheduled.SenderId)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			m.dropScheduledMessage(scheduled, err)
+			return
+		}
+		// Retried with the next sweep.
+		slog.Error(fmt.Sprintf("Error has occured while checking scheduled message %v: %v", scheduled.Id, err.Error()))
+		return
+	}
+	if !allowed {
+		m.dropScheduledMessage(scheduled, fmt.Errorf("%w: %v can not post in %v", errors.ErrPermissionDenied, scheduled.SenderId, scheduled.ChatRoomId))
+		return
+	}
+	message := scheduled.ToMessage()
+	message.CreatedAt = uint64(time.Now().UnixMilli())
+	message.ExpiresAt = expiryOf(message.CreatedAt, ttl)
+	promoted, err := m.messageRepository.PromoteScheduledMessage(scheduled, message)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while saving scheduled message %v: %v", scheduled.Id, err.Error()))
+		return
+	}
+	if !promoted {
+		return
+	}
+
+	messageWithTokens := models.MessageWithTokens{
+		Type:    dto.MessageTypeCreate,
+		Message: *message,
+		ActorId: scheduled.SenderId,
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(scheduled.RoomType), bytes)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while publishing scheduled message %v: %v", scheduled.Id, err.Error()))
+		return
+	}
+	slog.Debug(fmt.Sprintf("Scheduled message %v sent", scheduled.Id))
+}
+
+// dropScheduledMessage discards a scheduled message that can not be sent.
+func (m *MessageService) dropScheduledMessage(scheduled *models.ScheduledMessage, cause error) {
+	slog.Error(fmt.Sprintf("Dropping scheduled message %v: %v", scheduled.Id, cause))
+	err := m.messageRepository.DeleteScheduledMessage(scheduled.Id, scheduled.SenderId)
+	if err != nil && !e.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error(fmt.Sprintf("Error has occured while dropping scheduled message %v: %v", scheduled.Id, err.Error()))
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import "testing"
+
+func TestPrefixTsQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "empty", query: "", want: ""},
+		{name: "punctuation only", query: " ?!- ", want: ""},
+		{name: "one word", query: "Hello", want: "hello:*"},
+		{name: "several words", query: "  see you  tomorrow ", want: "see:* & you:* & tomorrow:*"},
+		{name: "tsquery operators", query: "cats & !dogs | (birds):*", want: "cats:* & dogs:* & birds:*"},
+		{name: "quotes", query: `it's "fine"`, want: "it:* & s:* & fine:*"},
+		{name: "digits", query: "room 101", want: "room:* & 101:*"},
+		{name: "non latin", query: "Привет, мир", want: "привет:* & мир:*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefixTsQuery(tt.query); got != tt.want {
+				t.Fatalf("prefixTsQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,23 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	e "errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
 
+	"example.com/chat-app/src/internal/client"
 	"example.com/chat-app/src/internal/dto"
 	"example.com/chat-app/src/internal/errors"
 	"example.com/chat-app/src/internal/models"
 	"example.com/chat-app/src/internal/repository"
-	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/jinzhu/gorm"
@@ -27,275 +33,1752 @@ func NewMessageHistoryService(messageRepository *repository.MessageRepository) *
 	}
 }
 
-func (m *MessageHistoryService) GetHistory(chatRoomId uuid.UUID) ([]models.Message, error) {
-	messages, err := m.messageRepository.GetMessageByChatRoomId(chatRoomId)
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 200
+)
+
+// GetHistory returns one page of a chat room history in chronological order.
+// Without cursors the newest page is returned. Before walks towards older
+// messages and After towards newer ones; they can not be combined. Thread
+// replies are not part of the history, their roots carry a summary instead.
+func (m *MessageHistoryService) GetHistory(chatRoomId uuid.UUID, historyReq *dto.HistoryRequest) (*models.HistoryPage, error) {
+	page, err := m.getPage(chatRoomId, nil, historyReq)
 	if err != nil {
 		return nil, err
 	}
-	return messages, nil
+	err = m.fillReplyContext(page.Messages)
+	if err != nil {
+		return nil, err
+	}
+	err = m.fillReactions(page.Messages)
+	if err != nil {
+		return nil, err
+	}
+	err = m.fillPolls(page.Messages)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
-type MessageService struct {
-	messageRepository                   *repository.MessageRepository
-	fileLoadedChannel                   chan *models.MessageIdXFileId
-	userIdXWsConnection                 map[uuid.UUID]*websocket.Conn
-	RedisChannelForChatRoomMessagesName string
-	RedisChannelForChannelMessagesName  string
-}
+// GetThread returns one page of the replies under rootId, paginated the same
+// way as the room history.
+func (m *MessageHistoryService) GetThread(chatRoomId uuid.UUID, rootId uuid.UUID, historyReq *dto.HistoryRequest) (*models.ThreadPage, error) {
+	root, err := m.messageRepository.GetMessageById(rootId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, rootId)
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if root.ChatRoomId != chatRoomId || root.ThreadRootId != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, rootId)
+	}
 
-func NewMessageService(messageRepository *repository.MessageRepository) *MessageService {
-	return &MessageService{
-		messageRepository:                   messageRepository,
-		fileLoadedChannel:                   make(chan *models.MessageIdXFileId),
-		userIdXWsConnection:                 make(map[uuid.UUID]*websocket.Conn),
-		RedisChannelForChatRoomMessagesName: "chat-room-messages-channel",
-		RedisChannelForChannelMessagesName:  "channel-messages-channel",
+	page, err := m.getPage(chatRoomId, &rootId, historyReq)
+	if err != nil {
+		return nil, err
+	}
+	messages := append([]models.Message{*root}, page.Messages...)
+	err = m.fillReplyContext(messages)
+	if err != nil {
+		return nil, err
 	}
+	err = m.fillReactions(messages)
+	if err != nil {
+		return nil, err
+	}
+	err = m.fillPolls(messages)
+	if err != nil {
+		return nil, err
+	}
+	if messages[0].Thread == nil {
+		messages[0].Thread = &models.ThreadSummary{RootId: rootId}
+	}
+	return &models.ThreadPage{Root: messages[0], HistoryPage: models.HistoryPage{
+		Messages:   messages[1:],
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}}, nil
 }
 
-func (m *MessageService) ListenFileChannel() {
-	var subscriber = m.messageRepository.SubscribeToRedisChannel("file-loaded-channel")
-	err := subscriber.Ping(context.Background())
-	if err != nil {
-		slog.Error("Not Available file-loaded-channel")
+// fillReplyContext attaches previews of the quoted messages and the thread
+// summaries of thread roots.
+func (m *MessageHistoryService) fillReplyContext(messages []models.Message) error {
+	if len(messages) == 0 {
+		return nil
 	}
-	slog.Info("Available file-loaded-channel")
-	for {
-		channel := subscriber.Channel()
-		message := <-channel
-		slog.Info(message.Payload)
-		mf := &models.MessageIdXFileId{}
-		err = json.Unmarshal([]byte(message.Payload), mf)
+	var replyToIds []uuid.UUID
+	rootIds := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		if message.ReplyToId != nil {
+			replyToIds = append(replyToIds, *message.ReplyToId)
+		}
+		if message.ThreadRootId == nil {
+			rootIds = append(rootIds, message.Id)
+		}
+	}
+
+	previews := make(map[uuid.UUID]*models.ReplyPreview, len(replyToIds))
+	if len(replyToIds) > 0 {
+		quoted, err := m.messageRepository.GetMessagesByIds(replyToIds)
 		if err != nil {
-			slog.Error(err.Error())
+			return fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+		}
+		for i := range quoted {
+			previews[quoted[i].Id] = models.NewReplyPreview(&quoted[i])
+		}
+	}
+	threads := make(map[uuid.UUID]*models.ThreadSummary)
+	if len(rootIds) > 0 {
+		summaries, err := m.messageRepository.GetThreadSummaries(rootIds)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+		}
+		for i := range summaries {
+			threads[summaries[i].RootId] = &summaries[i]
 		}
-		m.fileLoadedChannel <- mf
 	}
-}
 
-func (m *MessageService) Broadcast(userIds []uuid.UUID, readyMessage *models.ReadyMessage) {
-	for _, userId := range userIds {
-		slog.Debug(fmt.Sprintf("Checking if user %s is connected", userId))
-		wsConnection, ok := m.userIdXWsConnection[userId]
-		if ok {
-			slog.Debug(fmt.Sprintf("User %s is connected", userId))
-			messageResp := models.MapMessageToResponse(&readyMessage.Message)
-			slog.Debug(fmt.Sprintf("Sending message to %s", userId))
-			err := wsConnection.WriteJSON(messageResp)
-			if err != nil {
-				slog.Error(fmt.Sprintf("Disconnect user %s due to error %v", userId, err.Error()))
-				wsConnection.Close()
-				delete(m.userIdXWsConnection, userId)
-			}
-		} else {
-			_, err := m.messageRepository.GetUserStatusFromRedis(userId)
-			if err != nil {
-				slog.Debug(fmt.Sprintf("User %s is not connected", userId))
-				slog.Debug(fmt.Sprintf("Trying to notify user %v", userId))
-				bytes, err := json.Marshal(*readyMessage)
-				if err != nil {
-					slog.Error(err.Error())
-				}
-				m.messageRepository.PublishToRedisChannel("notification-channel", bytes)
-			}
+	for i := range messages {
+		if messages[i].ReplyToId != nil {
+			messages[i].ReplyPreview = previews[*messages[i].ReplyToId]
 		}
+		messages[i].Thread = threads[messages[i].Id]
 	}
+	return nil
 }
 
-func (m *MessageService) ReadMessagesFromChannel(userId uuid.UUID, wsConnection *websocket.Conn, accessToken string, refreshToken string) error {
-	var cerr error
-	err := m.messageRepository.SetUserStatusInRedis(userId)
+// GetPinnedMessages returns the pinned messages of a room, latest pin first.
+func (m *MessageHistoryService) GetPinnedMessages(chatRoomId uuid.UUID) ([]models.Message, error) {
+	pins, err := m.messageRepository.GetPins(chatRoomId)
 	if err != nil {
-		slog.Error(fmt.Sprintf("Error has occured while setting user status: %v", err.Error()))
-		cerr = fmt.Errorf("%w: %v", errors.ErrSetStatusRedis, err)
-		return cerr
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if len(pins) == 0 {
+		return []models.Message{}, nil
+	}
+	messageIds := make([]uuid.UUID, len(pins))
+	for i, pin := range pins {
+		messageIds[i] = pin.MessageId
+	}
+	found, err := m.messageRepository.GetMessagesByIds(messageIds)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	messageById := make(map[uuid.UUID]models.Message, len(found))
+	for _, message := range found {
+		messageById[message.Id] = message
 	}
-	m.userIdXWsConnection[userId] = wsConnection
-	slog.Debug(fmt.Sprintf("Added wsConnection to %v", userId))
 
-	for {
-		_, payload, err := wsConnection.ReadMessage()
-		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while reading message: %v", err))
-			cerr = fmt.Errorf("%w: %v", errors.ErrReadMessageError, err.Error())
-			break
+	messages := make([]models.Message, 0, len(pins))
+	for i := range pins {
+		message, ok := messageById[pins[i].MessageId]
+		if !ok {
+			continue
 		}
+		message.Pin = &pins[i]
+		messages = append(messages, message)
+	}
+	err = m.fillReplyContext(messages)
+	if err != nil {
+		return nil, err
+	}
+	err = m.fillReactions(messages)
+	if err != nil {
+		return nil, err
+	}
+	err = m.fillPolls(messages)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
 
-		messageReq := dto.MessageRequest{}
-
-		err = json.Unmarshal(payload, &messageReq)
-		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while unmarshalling message: %v", err))
-			cerr = fmt.Errorf("%w: %v", errors.ErrMapping, err)
-			break
+// fillReactions attaches the reaction counts of the messages that are not deleted.
+func (m *MessageHistoryService) fillReactions(messages []models.Message) error {
+	messageIds := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		if !message.IsDeleted {
+			messageIds = append(messageIds, message.Id)
 		}
+	}
+	if len(messageIds) == 0 {
+		return nil
+	}
+	counts, err := m.messageRepository.GetReactionCounts(messageIds)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	countsByMessage := make(map[uuid.UUID][]models.ReactionCount, len(messageIds))
+	for _, count := range counts {
+		countsByMessage[count.MessageId] = append(countsByMessage[count.MessageId], count)
+	}
+	for i := range messages {
+		messages[i].Reactions = countsByMessage[messages[i].Id]
+	}
+	return nil
+}
 
-		message, err := models.MapRequestToMessage(&messageReq)
-		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while mapping request to message: %v", err))
-			cerr = fmt.Errorf("%w: %v", errors.ErrMapping, err)
-			break
-		}
+func (m *MessageHistoryService) getPage(chatRoomId uuid.UUID, threadRootId *uuid.UUID, historyReq *dto.HistoryRequest) (*models.HistoryPage, error) {
+	limit := historyReq.Limit
+	if limit == 0 {
+		limit = DefaultHistoryLimit
+	}
+	if limit < 0 || limit > MaxHistoryLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", errors.ErrInvalidLimit, MaxHistoryLimit)
+	}
+	if historyReq.Before != "" && historyReq.After != "" {
+		return nil, fmt.Errorf("%w: before and after can not be used together", errors.ErrInvalidCursor)
+	}
 
-		mediaReceived := 0
-		if messageReq.WithMedia > 0 {
-			slog.Debug("Getting files")
-			for messageReq.WithMedia != mediaReceived {
-				mf := <-m.fileLoadedChannel
-				if mf.MessageId == message.Id {
-					metadata := dto.Metadata{}
-					metadata.FilePath = mf.FileId.String()
-					message.Metadata = metadata
-					mediaReceived++
-				} else {
-					m.fileLoadedChannel <- mf
-				}
-			}
-			slog.Debug("Files received")
+	var messages []models.Message
+	var err error
+	var hasOlder, hasNewer bool
+	switch {
+	case historyReq.After != "":
+		cursor, cerr := dto.DecodeCursor(historyReq.After)
+		if cerr != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidCursor, cerr)
 		}
-
-		slog.Debug("Saving message")
-		err = m.messageRepository.SaveUserMessage(message)
+		messages, err = m.messageRepository.GetMessagesAfter(chatRoomId, threadRootId, cursor.CreatedAt, cursor.Id, limit+1)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while saving message: %v", err.Error()))
-			if e.Is(err, gorm.ErrUnaddressable) || e.Is(err, gorm.ErrCantStartTransaction) {
-				cerr = fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err.Error())
-			} else {
-				cerr = fmt.Errorf("%w: %v", errors.ErrDataIntegrityViolation, err.Error())
-			}
-			break
+			return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
 		}
-		slog.Debug(fmt.Sprintf("Message Saved %v, %v", message.Id, message.Metadata.FilePath))
-
-		slog.Debug("Publishing Message")
-		messageWithTokens := models.MessageWithTokens{
-			Message:      *message,
-			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
+		hasOlder = true
+		hasNewer = len(messages) > limit
+		if hasNewer {
+			messages = messages[:limit]
+		}
+	case historyReq.Before != "":
+		cursor, cerr := dto.DecodeCursor(historyReq.Before)
+		if cerr != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidCursor, cerr)
 		}
-		bytes, err := json.Marshal(messageWithTokens)
+		messages, err = m.messageRepository.GetMessagesBefore(chatRoomId, threadRootId, cursor.CreatedAt, cursor.Id, limit+1)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while marshalling message: %v", err.Error()))
-			cerr = fmt.Errorf("%w: %v", errors.ErrMapping, err)
-			break
+			return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
 		}
-
-		err = m.messageRepository.PublishToRedisChannel(m.RedisChannelForChannelMessagesName, bytes)
+		hasNewer = true
+		hasOlder = len(messages) > limit
+		if hasOlder {
+			messages = messages[:limit]
+		}
+		slices.Reverse(messages)
+	default:
+		messages, err = m.messageRepository.GetLatestMessages(chatRoomId, threadRootId, limit+1)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while publishing message: %v", err.Error()))
-			cerr = fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
-			break
+			return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
 		}
-		slog.Debug(fmt.Sprintf("Message Published %v", bytes))
+		hasOlder = len(messages) > limit
+		if hasOlder {
+			messages = messages[:limit]
+		}
+		slices.Reverse(messages)
+	}
+
+	page := &models.HistoryPage{Messages: messages}
+	if len(messages) == 0 {
+		return page, nil
+	}
+	if hasOlder {
+		first := messages[0]
+		page.PrevCursor = dto.EncodeCursor(dto.HistoryCursor{CreatedAt: first.CreatedAt, Id: first.Id})
 	}
+	if hasNewer {
+		last := messages[len(messages)-1]
+		page.NextCursor = dto.EncodeCursor(dto.HistoryCursor{CreatedAt: last.CreatedAt, Id: last.Id})
+	}
+	return page, nil
+}
 
-	slog.Debug(fmt.Sprintf("Removing wsConnection from %v", userId))
-	delete(m.userIdXWsConnection, userId)
-	err = m.messageRepository.DropUserStatusInRedis(userId)
+// GetUnreadCounts returns how many messages of each room userId has not read
+// yet. Rooms without unread messages are reported with a zero count.
+func (m *MessageHistoryService) GetUnreadCounts(userId uuid.UUID, chatRoomIds []uuid.UUID) ([]models.UnreadCount, error) {
+	counts, err := m.messageRepository.CountUnread(userId, chatRoomIds)
 	if err != nil {
-		slog.Error(fmt.Sprintf("Error has occured while dropping user status in redis: %v", err.Error()))
-		cerr = fmt.Errorf("%w: %v", errors.ErrDropStatusRedis, err)
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
 	}
-	return cerr
+	countByRoom := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		countByRoom[count.ChatRoomId] = count.Count
+	}
+
+	result := make([]models.UnreadCount, 0, len(chatRoomIds))
+	for _, chatRoomId := range chatRoomIds {
+		result = append(result, models.UnreadCount{ChatRoomId: chatRoomId, Count: countByRoom[chatRoomId]})
+	}
+	return result, nil
 }
 
-func (m *MessageService) ReadMessagesFromChatRoom(userId uuid.UUID, wsConnection *websocket.Conn, accessToken string, refreshToken string) error {
-	var cerr error
-	err := m.messageRepository.SetUserStatusInRedis(userId)
+// GetInbox fills the latest message and the unread count of every
+// conversation and orders them by last activity, newest first. Rooms without
+// messages keep their order at the end.
+func (m *MessageHistoryService) GetInbox(userId uuid.UUID, conversations []models.Conversation) ([]models.Conversation, error) {
+	if len(conversations) == 0 {
+		return conversations, nil
+	}
+	chatRoomIds := make([]uuid.UUID, len(conversations))
+	for i, conversation := range conversations {
+		chatRoomIds[i] = conversation.ChatRoomId
+	}
+
+	lastMessages, err := m.messageRepository.GetLastMessages(chatRoomIds)
 	if err != nil {
-		slog.Error(fmt.Sprintf("Error has occured while setting user status: %v", err.Error()))
-		cerr = fmt.Errorf("%w: %v", errors.ErrSetStatusRedis, err)
-		return cerr
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	counts, err := m.messageRepository.CountUnread(userId, chatRoomIds)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	messageByRoom := make(map[uuid.UUID]*models.Message, len(lastMessages))
+	for i := range lastMessages {
+		messageByRoom[lastMessages[i].ChatRoomId] = &lastMessages[i]
+	}
+	countByRoom := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		countByRoom[count.ChatRoomId] = count.Count
 	}
-	m.userIdXWsConnection[userId] = wsConnection
-	slog.Debug(fmt.Sprintf("Added wsConnection to %v", userId))
 
-	for {
-		_, payload, err := wsConnection.ReadMessage()
-		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while reading message: %v", err))
-			cerr = fmt.Errorf("%w: %v", errors.ErrReadMessageError, err.Error())
-			break
-		}
+	for i := range conversations {
+		conversations[i].LastMessage = messageByRoom[conversations[i].ChatRoomId]
+		conversations[i].UnreadCount = countByRoom[conversations[i].ChatRoomId]
+	}
+	slices.SortStableFunc(conversations, func(a, b models.Conversation) int {
+		return cmp.Compare(lastActivity(b), lastActivity(a))
+	})
+	return conversations, nil
+}
 
-		messageReq := dto.MessageRequest{}
+func lastActivity(conversation models.Conversation) uint64 {
+	if conversation.LastMessage == nil {
+		return 0
+	}
+	return conversation.LastMessage.CreatedAt
+}
+
+// prefixTsQuery turns the words of query into a tsquery that matches every
+// word as a prefix. It is empty when query has no letter or digit.
+func prefixTsQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// SearchMessages runs a full-text search over the given rooms and returns one
+// page of matches, newest first. Every word of the query has to match, as a
+// prefix, for a message to be returned.
+func (m *MessageHistoryService) SearchMessages(chatRoomIds []uuid.UUID, searchReq *dto.SearchRequest) (*models.SearchPage, error) {
+	tsQuery := prefixTsQuery(searchReq.Query)
+	if tsQuery == "" {
+		return nil, fmt.Errorf("%w: query must contain a letter or digit", errors.ErrInvalidSearchQuery)
+	}
+	limit := searchReq.Limit
+	if limit == 0 {
+		limit = DefaultHistoryLimit
+	}
+	if limit < 0 || limit > MaxHistoryLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", errors.ErrInvalidLimit, MaxHistoryLimit)
+	}
+	if searchReq.To != 0 && searchReq.From > searchReq.To {
+		return nil, fmt.Errorf("%w: from must not be after to", errors.ErrInvalidSearchQuery)
+	}
 
-		err = json.Unmarshal(payload, &messageReq)
+	filter := &models.MessageSearchFilter{
+		ChatRoomIds:   chatRoomIds,
+		TsQuery:       tsQuery,
+		From:          searchReq.From,
+		To:            searchReq.To,
+		HasAttachment: searchReq.HasAttachment,
+	}
+	if searchReq.SenderId != "" {
+		senderId, err := uuid.Parse(searchReq.SenderId)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while unmarshalling message: %v", err))
-			cerr = fmt.Errorf("%w: %v", errors.ErrMapping, err)
-			break
+			return nil, fmt.Errorf("%w: invalid sender id: %v", errors.ErrInvalidSearchQuery, err)
 		}
-
-		message, err := models.MapRequestToMessage(&messageReq)
+		filter.SenderId = senderId
+	}
+	if searchReq.Cursor != "" {
+		cursor, err := dto.DecodeCursor(searchReq.Cursor)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while mapping request to message: %v", err))
-			cerr = fmt.Errorf("%w: %v", errors.ErrMapping, err)
-			break
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidCursor, err)
 		}
+		filter.Before = cursor
+	}
 
-		mediaReceived := 0
-		if messageReq.WithMedia > 0 {
-			slog.Debug("Getting files")
-			for messageReq.WithMedia != mediaReceived {
-				mf := <-m.fileLoadedChannel
-				if mf.MessageId == message.Id {
-					metadata := dto.Metadata{}
-					metadata.FilePath = mf.FileId.String()
-					message.Metadata = metadata
-					mediaReceived++
-				} else {
-					m.fileLoadedChannel <- mf
-				}
-			}
-			slog.Debug("Files received")
-		}
+	page := &models.SearchPage{}
+	if len(chatRoomIds) == 0 {
+		return page, nil
+	}
+	hits, err := m.messageRepository.SearchMessages(filter, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[len(hits)-1]
+		page.NextCursor = dto.EncodeCursor(dto.HistoryCursor{CreatedAt: last.CreatedAt, Id: last.Id})
+	}
+	page.Hits = hits
+	return page, nil
+}
+
+type MessageService struct {
+	messageRepository                 *repository.MessageRepository
+	chatMgmtClient                    *client.ChatMgmtGRPCClient
+	channelMgmtClient                 *client.ChanMgmtGRPCClient
+	hub                               *Hub
+	instanceId                        string
+	RedisQueueForChatRoomMessagesName string
+	RedisQueueForChannelMessagesName  string
+	RedisQueueForEphemeralName        string
+	maxPinsPerRoom                    int
+}
+
+const (
+	// MediaUploadTimeout is how long a message waits for its attachments
+	// before it is marked as failed.
+	MediaUploadTimeout = 10 * time.Minute
+	MediaSweepInterval = 30 * time.Second
+	MaxMediaPerMessage = 10
+)
+
+const DefaultMaxPinsPerRoom = 50
+
+const (
+	PresenceTTL               = 60 * time.Second
+	PresenceHeartbeatInterval = 20 * time.Second
+)
+
+// NewMessageService creates the service of one chat-app instance. Room events
+// are taken from shared Redis queues, so each is fanned out by exactly one
+// instance, and routed to the instances owning the receivers' connections.
+// An empty instanceId is replaced by a random one and a maxPinsPerRoom below
+// one by DefaultMaxPinsPerRoom.
+func NewMessageService(messageRepository *repository.MessageRepository, chatMgmtClient *client.ChatMgmtGRPCClient, channelMgmtClient *client.ChanMgmtGRPCClient, instanceId string, maxPinsPerRoom int) *MessageService {
+	if instanceId == "" {
+		instanceId = uuid.New().String()
+	}
+	if maxPinsPerRoom < 1 {
+		maxPinsPerRoom = DefaultMaxPinsPerRoom
+	}
+	return &MessageService{
+		messageRepository:                 messageRepository,
+		chatMgmtClient:                    chatMgmtClient,
+		channelMgmtClient:                 channelMgmtClient,
+		hub:                               NewHub(),
+		instanceId:                        instanceId,
+		RedisQueueForChatRoomMessagesName: "chat-room-messages-queue",
+		RedisQueueForChannelMessagesName:  "channel-messages-queue",
+		RedisQueueForEphemeralName:        "ephemeral-queue",
+		maxPinsPerRoom:                    maxPinsPerRoom,
+	}
+}
 
-		slog.Debug("Saving message")
-		err = m.messageRepository.SaveUserMessage(message)
+func instanceChannelName(instanceId string) string {
+	return "instance-channel:" + instanceId
+}
+
+func (m *MessageService) ListenFileChannel() {
+	var subscriber = m.messageRepository.SubscribeToRedisChannel("file-loaded-channel")
+	err := subscriber.Ping(context.Background())
+	if err != nil {
+		slog.Error("Not Available file-loaded-channel")
+	}
+	slog.Info("Available file-loaded-channel")
+	for {
+		channel := subscriber.Channel()
+		message := <-channel
+		slog.Info(message.Payload)
+		mf := &models.MessageIdXFileId{}
+		err = json.Unmarshal([]byte(message.Payload), mf)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while saving message: %v", err.Error()))
-			if e.Is(err, gorm.ErrUnaddressable) || e.Is(err, gorm.ErrCantStartTransaction) {
-				cerr = fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err.Error())
-			} else {
-				cerr = fmt.Errorf("%w: %v", errors.ErrDataIntegrityViolation, err.Error())
-			}
-			break
+			slog.Error(err.Error())
+			continue
 		}
-		slog.Debug(fmt.Sprintf("Message Saved %v, %v", message.Id, message.Metadata.FilePath))
+		m.collectMedia(mf.MessageId)
+	}
+}
 
-		slog.Debug("Publishing Message")
-		messageWithTokens := models.MessageWithTokens{
-			Message:      *message,
-			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
+// ListenChatDeletedChannel purges the history of chats deleted in
+// chat-management. Every instance receives the event, purging is idempotent.
+func (m *MessageService) ListenChatDeletedChannel() {
+	subscriber := m.messageRepository.SubscribeToRedisChannel("chat-deleted-channel")
+	err := subscriber.Ping(context.Background())
+	if err != nil {
+		slog.Error("Not Available chat-deleted-channel")
+		return
+	}
+	slog.Info("Available chat-deleted-channel")
+	for {
+		channel := subscriber.Channel()
+		message := <-channel
+		event := &models.ChatDeletedEvent{}
+		err = json.Unmarshal([]byte(message.Payload), event)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
 		}
-		bytes, err := json.Marshal(messageWithTokens)
+		err = m.messageRepository.DeleteChatRoomMessages(event.ChatId)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while marshalling message: %v", err.Error()))
-			cerr = fmt.Errorf("%w: %v", errors.ErrMapping, err)
-			break
+			slog.Error("Failed to purge messages of deleted chat", "chatID", event.ChatId, "error", err.Error())
+			continue
 		}
+		slog.Info("Purged messages of deleted chat", "chatID", event.ChatId)
+	}
+}
 
-		err = m.messageRepository.PublishToRedisChannel(m.RedisChannelForChatRoomMessagesName, bytes)
+// SweepPendingMedia marks messages whose attachments did not arrive within
+// MediaUploadTimeout as failed.
+func (m *MessageService) SweepPendingMedia() {
+	ticker := time.NewTicker(MediaSweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		messageIds, err := m.messageRepository.GetExpiredPendingMediaFromRedis(time.Now())
 		if err != nil {
-			slog.Error(fmt.Sprintf("Error has occured while publishing message: %v", err.Error()))
-			cerr = fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
-			break
+			slog.Error(fmt.Sprintf("Error has occured while getting expired media: %v", err.Error()))
+			continue
+		}
+		for _, rawId := range messageIds {
+			messageId, err := uuid.Parse(rawId)
+			if err != nil {
+				slog.Error(err.Error())
+				continue
+			}
+			m.finishMedia(messageId, dto.MediaStatusFailed)
 		}
-		slog.Debug(fmt.Sprintf("Message Published %v", bytes))
 	}
+}
 
-	slog.Debug(fmt.Sprintf("Removing wsConnection from %v", userId))
-	delete(m.userIdXWsConnection, userId)
-	err = m.messageRepository.DropUserStatusInRedis(userId)
+func (m *MessageService) registerPendingMedia(roomType models.RoomType, messageWithTokens *models.MessageWithTokens) error {
+	bytes, err := json.Marshal(models.PendingMedia{RoomType: roomType, MessageWithTokens: *messageWithTokens})
 	if err != nil {
-		slog.Error(fmt.Sprintf("Error has occured while dropping user status in redis: %v", err.Error()))
-		cerr = fmt.Errorf("%w: %v", errors.ErrDropStatusRedis, err)
+		return fmt.Errorf("%w: %v", errors.ErrMapping, err)
 	}
-	return cerr
+	err = m.messageRepository.SavePendingMediaInRedis(messageWithTokens.Message.Id, bytes, time.Now().Add(MediaUploadTimeout))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrSetStatusRedis, err)
+	}
+	return nil
+}
+
+func (m *MessageService) getPendingMedia(messageId uuid.UUID) (*models.PendingMedia, error) {
+	payload, err := m.messageRepository.GetPendingMediaFromRedis(messageId)
+	if err != nil {
+		return nil, err
+	}
+	pendingMedia := &models.PendingMedia{}
+	err = json.Unmarshal([]byte(payload), pendingMedia)
+	if err != nil {
+		return nil, err
+	}
+	return pendingMedia, nil
+}
+
+// collectMedia attaches every file uploaded so far to a pending message and
+// finishes it once all expected files are there. It is idempotent, so every
+// instance may run it for every uploaded file.
+func (m *MessageService) collectMedia(messageId uuid.UUID) {
+	pendingMedia, err := m.getPendingMedia(messageId)
+	if err != nil {
+		// Not sent yet or already finished; the upload stays in the hash.
+		slog.Debug(fmt.Sprintf("No pending media for message %v: %v", messageId, err))
+		return
+	}
+	files, err := m.messageRepository.GetMessageFilesFromRedis(messageId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while getting message files: %v", err.Error()))
+		return
+	}
+	if len(files) >= pendingMedia.MessageWithTokens.Message.WithMedia {
+		m.finishMedia(messageId, dto.MediaStatusComplete)
+		return
+	}
+
+	metadata := pendingMedia.MessageWithTokens.Message.Metadata
+	metadata.Attachments = mapFilesToAttachments(files)
+	err = m.messageRepository.UpdatePendingMessageMetadata(messageId, metadata)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while updating message metadata: %v", err.Error()))
+	}
+}
+
+// finishMedia stores the final attachments and status of a pending message
+// and publishes it to the room.
+func (m *MessageService) finishMedia(messageId uuid.UUID, mediaStatus string) {
+	pendingMedia, err := m.getPendingMedia(messageId)
+	if err != nil {
+		slog.Debug(fmt.Sprintf("No pending media for message %v: %v", messageId, err))
+		return
+	}
+	claimed, err := m.messageRepository.ClaimPendingMediaInRedis(messageId)
+	if err != nil || !claimed {
+		return
+	}
+	files, err := m.messageRepository.GetMessageFilesFromRedis(messageId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while getting message files: %v", err.Error()))
+	}
+
+	messageWithTokens := pendingMedia.MessageWithTokens
+	messageWithTokens.Type = dto.MessageTypeMedia
+	messageWithTokens.Message.Metadata.MediaStatus = mediaStatus
+	messageWithTokens.Message.Metadata.Attachments = mapFilesToAttachments(files)
+	err = m.messageRepository.UpdateMessageMetadata(messageId, messageWithTokens.Message.Metadata)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while updating message metadata: %v", err.Error()))
+		return
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(pendingMedia.RoomType), bytes)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while publishing media of %v: %v", messageId, err.Error()))
+		return
+	}
+	slog.Debug(fmt.Sprintf("Media of message %v %v", messageId, mediaStatus))
+}
+
+func mapFilesToAttachments(files []models.MessageIdXFileId) []dto.Attachment {
+	attachments := make([]dto.Attachment, 0, len(files))
+	for _, file := range files {
+		attachments = append(attachments, models.MapFileToAttachment(&file))
+	}
+	return attachments
+}
+
+// ListenInstanceChannel delivers events other instances routed to the
+// connections held by this one.
+func (m *MessageService) ListenInstanceChannel() {
+	channelName := instanceChannelName(m.instanceId)
+	subscriber := m.messageRepository.SubscribeToRedisChannel(channelName)
+	err := subscriber.Ping(context.Background())
+	if err != nil {
+		slog.Error("Channel Not Available", "channel", channelName)
+		return
+	}
+	slog.Info("Channel Available", "channel", channelName)
+	for {
+		channel := subscriber.Channel()
+		message := <-channel
+		routedEvent := &models.RoutedEvent{}
+		err = json.Unmarshal([]byte(message.Payload), routedEvent)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		if routedEvent.ReadyMessage != nil {
+			m.deliverMessage(routedEvent.ReceiverIds, routedEvent.ReadyMessage)
+			continue
+		}
+		if routedEvent.Envelope != nil {
+			m.deliverEnvelope(routedEvent.ReceiverIds, routedEvent.Envelope)
+		}
+	}
+}
+
+// routeReceivers splits userIds into receivers with sessions on this
+// instance, receivers with sessions on other instances grouped by instance id
+// and offline receivers. A user with several devices may be both local and
+// remote.
+func (m *MessageService) routeReceivers(userIds []uuid.UUID) ([]uuid.UUID, map[string][]uuid.UUID, []uuid.UUID) {
+	var local, offline []uuid.UUID
+	remote := make(map[string][]uuid.UUID)
+	for _, userId := range userIds {
+		if m.hub.IsConnected(userId) {
+			local = append(local, userId)
+		}
+	}
+
+	instances, err := m.messageRepository.GetUserInstancesFromRedis(userIds)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while getting user statuses: %v", err.Error()))
+		for _, userId := range userIds {
+			if !m.hub.IsConnected(userId) {
+				offline = append(offline, userId)
+			}
+		}
+		return local, remote, offline
+	}
+	for i, userId := range userIds {
+		online := m.hub.IsConnected(userId)
+		for _, instanceId := range instances[i] {
+			if instanceId == m.instanceId {
+				continue
+			}
+			remote[instanceId] = append(remote[instanceId], userId)
+			online = true
+		}
+		if !online {
+			offline = append(offline, userId)
+		}
+	}
+	return local, remote, offline
+}
+
+func (m *MessageService) forward(instanceId string, routedEvent *models.RoutedEvent) {
+	bytes, err := json.Marshal(routedEvent)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while marshalling routed event: %v", err.Error()))
+		return
+	}
+	err = m.messageRepository.PublishToRedisChannel(instanceChannelName(instanceId), bytes)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while forwarding to instance %v: %v", instanceId, err.Error()))
+	}
+}
+
+// routeEnvelope writes envelope to every connected receiver wherever its
+// connection lives. Offline receivers are skipped.
+func (m *MessageService) routeEnvelope(userIds []uuid.UUID, envelope *dto.Envelope) {
+	local, remote, _ := m.routeReceivers(userIds)
+	m.deliverEnvelope(local, envelope)
+	for instanceId, receiverIds := range remote {
+		m.forward(instanceId, &models.RoutedEvent{ReceiverIds: receiverIds, Envelope: envelope})
+	}
+}
+
+func (m *MessageService) deliverEnvelope(userIds []uuid.UUID, envelope *dto.Envelope) {
+	for _, userId := range userIds {
+		for _, session := range m.hub.SessionsOf(userId) {
+			err := session.writeEnvelope(envelope)
+			if err != nil {
+				slog.Error(fmt.Sprintf("Error has occured while queueing %v frame to session %v of %v: %v", envelope.Type, session.id, userId, err.Error()))
+			}
+		}
+	}
+}
+
+func (m *MessageService) publishReceipt(event *models.ReceiptEvent) {
+	envelope, err := dto.NewEnvelope(dto.FrameTypeReceipt, "", models.MapReceiptEventToResponse(event))
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while marshalling receipt: %v", err.Error()))
+		return
+	}
+	m.routeEnvelope(event.ReceiverIds, envelope)
+}
+
+// recordDelivery stores that message reached userId and lets the sender know.
+func (m *MessageService) recordDelivery(message *models.Message, userId uuid.UUID) {
+	deliveredAt := uint64(time.Now().UnixMilli())
+	err := m.messageRepository.MarkDelivered(&models.MessageReceipt{
+		MessageId:   message.Id,
+		UserId:      userId,
+		ChatRoomId:  message.ChatRoomId,
+		DeliveredAt: deliveredAt,
+	})
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while saving delivery receipt: %v", err.Error()))
+		return
+	}
+	m.publishReceipt(&models.ReceiptEvent{
+		Status:      dto.ReceiptStatusDelivered,
+		MessageId:   message.Id,
+		ChatRoomId:  message.ChatRoomId,
+		UserId:      userId,
+		Timestamp:   deliveredAt,
+		ReceiverIds: []uuid.UUID{message.SenderId},
+	})
+}
+
+// Broadcast delivers a message event to its receivers. Receivers connected to
+// other instances are forwarded to them and offline receivers of new messages
+// get a push notification.
+func (m *MessageService) Broadcast(userIds []uuid.UUID, readyMessage *models.ReadyMessage) {
+	local, remote, offline := m.routeReceivers(userIds)
+	m.deliverMessage(local, readyMessage)
+	for instanceId, receiverIds := range remote {
+		slog.Debug(fmt.Sprintf("Forwarding message to instance %s", instanceId))
+		m.forward(instanceId, &models.RoutedEvent{ReceiverIds: receiverIds, ReadyMessage: readyMessage})
+	}
+
+	if readyMessage.Type != "" && readyMessage.Type != dto.MessageTypeCreate {
+		return
+	}
+	if readyMessage.Message.ThreadRootId != nil {
+		offline = m.threadReplyReceivers(userIds, &readyMessage.Message)
+	}
+	if len(offline) == 0 {
+		return
+	}
+	slog.Debug(fmt.Sprintf("Trying to notify users %v", offline))
+	notification := *readyMessage
+	notification.ReceiversIds = offline
+	bytes, err := json.Marshal(notification)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	m.messageRepository.PublishToRedisChannel("notification-channel", bytes)
+}
+
+// threadReplyReceivers returns the author of the thread root when it should
+// be notified of a reply. Thread replies stay out of the channel history, so
+// the author is notified whether connected or not, and nobody else is.
+func (m *MessageService) threadReplyReceivers(userIds []uuid.UUID, message *models.Message) []uuid.UUID {
+	root, err := m.messageRepository.GetMessageById(*message.ThreadRootId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while getting thread root %v: %v", *message.ThreadRootId, err.Error()))
+		return nil
+	}
+	if root.SenderId == message.SenderId || !slices.Contains(userIds, root.SenderId) {
+		return nil
+	}
+	return []uuid.UUID{root.SenderId}
+}
+
+// deliverMessage queues a message event to every session of the receivers
+// connected to this instance. Delivery of new messages is recorded once per
+// receiver, as soon as the first of its sessions has written the frame.
+func (m *MessageService) deliverMessage(userIds []uuid.UUID, readyMessage *models.ReadyMessage) {
+	eventType := readyMessage.Type
+	if eventType == "" {
+		eventType = dto.MessageTypeCreate
+	}
+	messageResp := models.MapMessageToResponse(&readyMessage.Message)
+	messageResp.Type = eventType
+	envelope, err := dto.NewEnvelope(eventType, "", messageResp)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	for _, userId := range userIds {
+		var onWritten func()
+		if eventType == dto.MessageTypeCreate && userId != readyMessage.Message.SenderId {
+			receiverId := userId
+			once := &sync.Once{}
+			onWritten = func() {
+				once.Do(func() { go m.recordDelivery(&readyMessage.Message, receiverId) })
+			}
+		}
+		for _, session := range m.hub.SessionsOf(userId) {
+			slog.Debug(fmt.Sprintf("Sending message to session %s of %s", session.id, userId))
+			err = session.enqueue(envelope, onWritten)
+			if err != nil {
+				slog.Error(fmt.Sprintf("Error has occured while queueing message to session %s of %s: %v", session.id, userId, err.Error()))
+			}
+		}
+	}
+}
+
+// BroadcastEphemeral delivers a typing or presence event to the connected
+// participants other than the user who caused it.
+func (m *MessageService) BroadcastEphemeral(userIds []uuid.UUID, event *models.EphemeralEvent) {
+	envelope, err := dto.NewEnvelope(event.Type, "", models.MapEphemeralEventToResponse(event))
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	receiverIds := make([]uuid.UUID, 0, len(userIds))
+	for _, userId := range userIds {
+		if userId != event.UserId {
+			receiverIds = append(receiverIds, userId)
+		}
+	}
+	m.routeEnvelope(receiverIds, envelope)
+}
+
+func (m *MessageService) publishEphemeral(event *models.EphemeralEvent) error {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	err = m.messageRepository.PushToRedisQueue(m.RedisQueueForEphemeralName, bytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	return nil
+}
+
+// heartbeatPresence keeps the session in the user's presence set until done
+// is closed. A crashed instance stops heartbeating and the session expires.
+func (m *MessageService) heartbeatPresence(session *wsSession, done <-chan struct{}) {
+	ticker := time.NewTicker(PresenceHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			err := m.messageRepository.SetUserStatusInRedis(session.userId, m.instanceId, session.id, PresenceTTL, uint64(time.Now().UnixMilli()))
+			if err != nil {
+				slog.Error(fmt.Sprintf("Error has occured while refreshing user status: %v", err.Error()))
+			}
+		}
+	}
+}
+
+func (m *MessageService) ReadMessagesFromChannel(userId uuid.UUID, wsConnection *websocket.Conn, accessToken string, refreshToken string) error {
+	return m.readMessages(userId, models.ChannelRoomType, wsConnection, accessToken, refreshToken)
+}
+
+func (m *MessageService) ReadMessagesFromChatRoom(userId uuid.UUID, wsConnection *websocket.Conn, accessToken string, refreshToken string) error {
+	return m.readMessages(userId, models.ChatRoomType, wsConnection, accessToken, refreshToken)
+}
+
+// readMessages serves one websocket session. Every frame is answered with an
+// ack or an error envelope; only a failed read ends the session.
+func (m *MessageService) readMessages(userId uuid.UUID, roomType models.RoomType, wsConnection *websocket.Conn, accessToken string, refreshToken string) error {
+	var cerr error
+	session := newWsSession(userId, wsConnection)
+	err := m.messageRepository.SetUserStatusInRedis(userId, m.instanceId, session.id, PresenceTTL, uint64(time.Now().UnixMilli()))
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while setting user status: %v", err.Error()))
+		cerr = fmt.Errorf("%w: %v", errors.ErrSetStatusRedis, err)
+		return cerr
+	}
+	stopHeartbeat := make(chan struct{})
+	go m.heartbeatPresence(session, stopHeartbeat)
+	go session.writePump()
+	session.startKeepalive()
+	m.hub.Add(session)
+	slog.Debug(fmt.Sprintf("Added session %v to %v", session.id, userId))
+
+	for {
+		_, payload, err := wsConnection.ReadMessage()
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while reading message: %v", err))
+			cerr = fmt.Errorf("%w: %v", errors.ErrReadMessageError, err.Error())
+			break
+		}
+
+		envelope := dto.Envelope{}
+		err = json.Unmarshal(payload, &envelope)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while unmarshalling envelope: %v", err))
+			m.replyError(session, "", fmt.Errorf("%w: %v", errors.ErrMapping, err))
+			continue
+		}
+
+		ack, err := m.handleEnvelope(userId, roomType, &envelope, accessToken, refreshToken)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while handling %v frame: %v", envelope.Type, err.Error()))
+			m.replyError(session, envelope.RequestId, err)
+			continue
+		}
+		reply, err := dto.NewEnvelope(dto.FrameTypeAck, envelope.RequestId, ack)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		err = session.writeEnvelope(reply)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while queueing ack: %v", err.Error()))
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Removing session %v from %v", session.id, userId))
+	close(stopHeartbeat)
+	m.hub.Remove(session)
+	session.close()
+	<-session.writerDone
+	lastSeen := uint64(time.Now().UnixMilli())
+	remaining, err := m.messageRepository.DropUserStatusInRedis(userId, m.instanceId, session.id, lastSeen)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while dropping user status in redis: %v", err.Error()))
+		return fmt.Errorf("%w: %v", errors.ErrDropStatusRedis, err)
+	}
+	if remaining == 0 {
+		m.announceOffline(userId, lastSeen, accessToken, refreshToken)
+	}
+	return cerr
+}
+
+// announceOffline tells every room the user announced presence in that the
+// last session of the user has ended.
+func (m *MessageService) announceOffline(userId uuid.UUID, lastSeen uint64, accessToken string, refreshToken string) {
+	rooms, err := m.messageRepository.PopPresenceRoomsFromRedis(userId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while getting presence rooms: %v", err.Error()))
+		return
+	}
+	for _, room := range rooms {
+		roomType, rawId, _ := strings.Cut(room, "/")
+		chatRoomId, err := uuid.Parse(rawId)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		err = m.publishEphemeral(&models.EphemeralEvent{
+			Type:         dto.FrameTypePresence,
+			RoomType:     models.RoomType(roomType),
+			ChatRoomId:   chatRoomId,
+			UserId:       userId,
+			Status:       dto.PresenceStatusOffline,
+			LastSeen:     lastSeen,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		})
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while publishing presence: %v", err.Error()))
+		}
+	}
+}
+
+func (m *MessageService) handleEnvelope(userId uuid.UUID, roomType models.RoomType, envelope *dto.Envelope, accessToken string, refreshToken string) (*dto.AckPayload, error) {
+	if envelope.Version != dto.ProtocolVersion {
+		return nil, fmt.Errorf("%w: %d", errors.ErrUnsupportedVersion, envelope.Version)
+	}
+
+	var message *models.Message
+	switch envelope.Type {
+	case dto.MessageTypeCreate, dto.MessageTypeEdit, dto.MessageTypeDelete:
+		messageReq := dto.MessageRequest{}
+		err := json.Unmarshal(envelope.Payload, &messageReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		messageReq.Type = envelope.Type
+		if envelope.Type == dto.MessageTypeCreate {
+			message, err = m.SendMessage(userId, roomType, &messageReq, accessToken, refreshToken)
+		} else {
+			message, err = m.ModifyMessage(userId, roomType, &messageReq, accessToken, refreshToken)
+		}
+		if err != nil {
+			return nil, err
+		}
+	case dto.FrameTypeRead:
+		readReq := dto.ReadRequest{}
+		err := json.Unmarshal(envelope.Payload, &readReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		message, err = m.MarkRead(userId, roomType, &readReq, accessToken, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+	case dto.FrameTypeReactionAdd, dto.FrameTypeReactionRemove:
+		reactionReq := dto.ReactionRequest{}
+		err := json.Unmarshal(envelope.Payload, &reactionReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		message, err = m.React(userId, roomType, &reactionReq, envelope.Type == dto.FrameTypeReactionAdd, accessToken, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+	case dto.FrameTypePin, dto.FrameTypeUnpin:
+		pinReq := dto.PinRequest{}
+		err := json.Unmarshal(envelope.Payload, &pinReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		message, err = m.Pin(userId, roomType, &pinReq, envelope.Type == dto.FrameTypePin, accessToken, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+	case dto.FrameTypeForward:
+		forwardReq := dto.ForwardRequest{}
+		err := json.Unmarshal(envelope.Payload, &forwardReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		message, err = m.Forward(userId, roomType, &forwardReq, accessToken, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+	case dto.FrameTypeVote:
+		voteReq := dto.VoteRequest{}
+		err := json.Unmarshal(envelope.Payload, &voteReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		message, err = m.Vote(userId, roomType, &voteReq, accessToken, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+	case dto.FrameTypeTyping:
+		typingReq := dto.TypingRequest{}
+		err := json.Unmarshal(envelope.Payload, &typingReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		err = m.SendTyping(userId, roomType, &typingReq, accessToken, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+	case dto.FrameTypePresence:
+		presenceReq := dto.PresenceRequest{}
+		err := json.Unmarshal(envelope.Payload, &presenceReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+		}
+		err = m.AnnouncePresence(userId, roomType, &presenceReq, accessToken, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", errors.ErrUnknownFrameType, envelope.Type)
+	}
+
+	ack := &dto.AckPayload{ServerTimestamp: uint64(time.Now().UnixMilli())}
+	if message != nil {
+		ack.MessageId = message.Id.String()
+	}
+	return ack, nil
+}
+
+// SendTyping publishes a typing started or stopped event for the room the
+// user belongs to.
+func (m *MessageService) SendTyping(userId uuid.UUID, roomType models.RoomType, typingReq *dto.TypingRequest, accessToken string, refreshToken string) error {
+	chatRoomId, err := uuid.Parse(typingReq.ChatRoomId)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	isParticipant, err := m.isRoomParticipant(roomType, chatRoomId, accessToken, refreshToken, userId)
+	if err != nil || !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", userId, chatRoomId), "error", err)
+		return fmt.Errorf("%w: %v is not a participant of %v", errors.ErrPermissionDenied, userId, chatRoomId)
+	}
+	return m.publishEphemeral(&models.EphemeralEvent{
+		Type:         dto.FrameTypeTyping,
+		RoomType:     roomType,
+		ChatRoomId:   chatRoomId,
+		UserId:       userId,
+		Typing:       typingReq.Typing,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// AnnouncePresence tells the participants of a room the user belongs to that
+// the user is online and remembers the room so an offline event follows when
+// the last session of the user ends.
+func (m *MessageService) AnnouncePresence(userId uuid.UUID, roomType models.RoomType, presenceReq *dto.PresenceRequest, accessToken string, refreshToken string) error {
+	chatRoomId, err := uuid.Parse(presenceReq.ChatRoomId)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	isParticipant, err := m.isRoomParticipant(roomType, chatRoomId, accessToken, refreshToken, userId)
+	if err != nil || !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", userId, chatRoomId), "error", err)
+		return fmt.Errorf("%w: %v is not a participant of %v", errors.ErrPermissionDenied, userId, chatRoomId)
+	}
+	err = m.publishEphemeral(&models.EphemeralEvent{
+		Type:         dto.FrameTypePresence,
+		RoomType:     roomType,
+		ChatRoomId:   chatRoomId,
+		UserId:       userId,
+		Status:       dto.PresenceStatusOnline,
+		LastSeen:     uint64(time.Now().UnixMilli()),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return err
+	}
+	err = m.messageRepository.AddPresenceRoomInRedis(userId, fmt.Sprintf("%s/%s", roomType, chatRoomId))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrSetStatusRedis, err)
+	}
+	return nil
+}
+
+func (m *MessageService) replyError(session *wsSession, requestId string, cause error) {
+	errorPayload := &dto.ErrorPayload{
+		Code:  errorCode(cause),
+		Error: cause.Error(),
+	}
+	envelope, err := dto.NewEnvelope(dto.FrameTypeError, requestId, errorPayload)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	err = session.writeEnvelope(envelope)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while queueing error frame: %v", err.Error()))
+	}
+}
+
+func errorCode(err error) string {
+	switch {
+	case e.Is(err, errors.ErrPermissionDenied):
+		return dto.ErrorCodeForbidden
+	case e.Is(err, errors.ErrMessageNotFound):
+		return dto.ErrorCodeNotFound
+	case e.Is(err, errors.ErrDatabaseInternalError), e.Is(err, errors.ErrPublishMessageError):
+		return dto.ErrorCodeInternal
+	default:
+		return dto.ErrorCodeBadRequest
+	}
+}
+
+// SendMessage persists a new message and publishes it for fan-out. A message
+// with media is published as pending; a media event follows once its
+// attachments are complete or MediaUploadTimeout has passed.
+func (m *MessageService) SendMessage(userId uuid.UUID, roomType models.RoomType, messageReq *dto.MessageRequest, accessToken string, refreshToken string) (*models.Message, error) {
+	message, err := models.MapRequestToMessage(messageReq)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while mapping request to message: %v", err))
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	if message.SenderId != userId {
+		return nil, fmt.Errorf("%w: sender id does not match the session user", errors.ErrPermissionDenied)
+	}
+
+	if message.WithMedia < 0 || message.WithMedia > MaxMediaPerMessage {
+		return nil, fmt.Errorf("%w: withMedia must be between 0 and %d", errors.ErrMapping, MaxMediaPerMessage)
+	}
+	if message.WithMedia > 0 {
+		message.Metadata.MediaStatus = dto.MediaStatusPending
+	}
+	err = checkContent(message)
+	if err != nil {
+		return nil, err
+	}
+	err = m.checkReply(roomType, message)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := m.checkPost(roomType, message.ChatRoomId, userId, accessToken, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	// The client clock decides neither the order of the history nor when the
+	// message disappears.
+	message.CreatedAt = uint64(time.Now().UnixMilli())
+	message.ExpiresAt = expiryOf(message.CreatedAt, ttl)
+
+	slog.Debug("Saving message")
+	err = m.messageRepository.SaveUserMessage(message)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while saving message: %v", err.Error()))
+		if e.Is(err, gorm.ErrUnaddressable) || e.Is(err, gorm.ErrCantStartTransaction) {
+			return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err.Error())
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDataIntegrityViolation, err.Error())
+	}
+	slog.Debug(fmt.Sprintf("Message Saved %v", message.Id))
+
+	slog.Debug("Publishing Message")
+	messageWithTokens := models.MessageWithTokens{
+		Type:         dto.MessageTypeCreate,
+		Message:      *message,
+		ActorId:      userId,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while marshalling message: %v", err.Error()))
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+
+	if message.WithMedia > 0 {
+		err = m.registerPendingMedia(roomType, &messageWithTokens)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Error has occured while registering pending media: %v", err.Error()))
+			return nil, err
+		}
+	}
+
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(roomType), bytes)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while publishing message: %v", err.Error()))
+		return nil, fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	slog.Debug(fmt.Sprintf("Message Published %v", bytes))
+
+	if message.WithMedia > 0 {
+		// Files uploaded before the message was sent are already waiting.
+		m.collectMedia(message.Id)
+	}
+	return message, nil
+}
+
+// checkReply makes sure the quoted message and the thread root of a new
+// message belong to its room. Threads only exist in channels, are one level
+// deep and a reply inside a thread may only quote messages of that thread.
+func (m *MessageService) checkReply(roomType models.RoomType, message *models.Message) error {
+	if message.ThreadRootId != nil {
+		if roomType != models.ChannelRoomType {
+			return fmt.Errorf("%w: threads are only available in channels", errors.ErrInvalidReply)
+		}
+		root, err := m.getRoomMessage(message.ChatRoomId, *message.ThreadRootId)
+		if err != nil {
+			return err
+		}
+		if root.ThreadRootId != nil {
+			return fmt.Errorf("%w: %v is a thread reply itself", errors.ErrInvalidReply, root.Id)
+		}
+	}
+	if message.ReplyToId == nil {
+		return nil
+	}
+
+	quoted, err := m.getRoomMessage(message.ChatRoomId, *message.ReplyToId)
+	if err != nil {
+		return err
+	}
+	if message.ThreadRootId == nil && quoted.ThreadRootId != nil {
+		return fmt.Errorf("%w: %v belongs to a thread", errors.ErrInvalidReply, quoted.Id)
+	}
+	if message.ThreadRootId != nil && quoted.Id != *message.ThreadRootId &&
+		(quoted.ThreadRootId == nil || *quoted.ThreadRootId != *message.ThreadRootId) {
+		return fmt.Errorf("%w: %v is not part of the thread", errors.ErrInvalidReply, quoted.Id)
+	}
+	message.ReplyPreview = models.NewReplyPreview(quoted)
+	return nil
+}
+
+// getRoomMessage loads a message that is not deleted and belongs to the room.
+func (m *MessageService) getRoomMessage(chatRoomId uuid.UUID, messageId uuid.UUID) (*models.Message, error) {
+	message, err := m.messageRepository.GetMessageById(messageId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, messageId)
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if message.IsDeleted || message.ChatRoomId != chatRoomId {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, messageId)
+	}
+	return message, nil
+}
+
+// ModifyMessage applies an edit or a delete frame. Only the original sender or
+// an admin of the room may modify a message. The result is published through
+// the same Redis channel as new messages so every participant receives it.
+func (m *MessageService) ModifyMessage(userId uuid.UUID, roomType models.RoomType, messageReq *dto.MessageRequest, accessToken string, refreshToken string) (*models.Message, error) {
+	messageId, err := uuid.Parse(messageReq.MessageId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	message, err := m.messageRepository.GetMessageById(messageId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, messageId)
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if message.IsDeleted || message.ChatRoomId.String() != messageReq.ChatRoomId {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, messageId)
+	}
+
+	// Anyone but the sender needs a role that allows editing or deleting
+	// messages of the room.
+	if message.SenderId != userId {
+		permission := models.PermissionEditMessages
+		if messageReq.Type == dto.MessageTypeDelete {
+			permission = models.PermissionDeleteMessages
+		}
+		allowed, err := m.hasRoomPermission(roomType, message.ChatRoomId, accessToken, refreshToken, userId, permission)
+		if err != nil || !allowed {
+			slog.Error(fmt.Sprintf("Permission denied: %v can not modify message %v", userId, messageId), "error", err)
+			return nil, fmt.Errorf("%w: %v can not modify message %v", errors.ErrPermissionDenied, userId, messageId)
+		}
+	}
+
+	switch messageReq.Type {
+	case dto.MessageTypeEdit:
+		if messageReq.Body == "" {
+			return nil, fmt.Errorf("%w: edited message body is empty", errors.ErrMapping)
+		}
+		if message.ContentType != dto.ContentTypeText {
+			return nil, fmt.Errorf("%w: only text messages can be edited", errors.ErrInvalidContent)
+		}
+		err = m.messageRepository.EditMessage(message, userId, messageReq.Body, uint64(time.Now().UnixMilli()))
+	case dto.MessageTypeDelete:
+		err = m.messageRepository.TombstoneMessage(message)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+
+	messageWithTokens := models.MessageWithTokens{
+		Type:         messageReq.Type,
+		Message:      *message,
+		ActorId:      userId,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(roomType), bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	slog.Debug(fmt.Sprintf("Message %v %v published", messageReq.Type, messageId))
+	return message, nil
+}
+
+// Forward copies a message of a room the user belongs to into another room
+// the user may post in, which can be a chat or a channel. The copy is a new
+// message of the user and is published to the target room.
+func (m *MessageService) Forward(userId uuid.UUID, roomType models.RoomType, forwardReq *dto.ForwardRequest, accessToken string, refreshToken string) (*models.Message, error) {
+	chatRoomId, err := uuid.Parse(forwardReq.ChatRoomId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	messageId, err := uuid.Parse(forwardReq.MessageId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	targetRoomId, err := uuid.Parse(forwardReq.TargetChatRoomId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	targetRoomType := roomType
+	if forwardReq.TargetRoomType != "" {
+		targetRoomType = models.RoomType(forwardReq.TargetRoomType)
+	}
+	if targetRoomType != models.ChatRoomType && targetRoomType != models.ChannelRoomType {
+		return nil, fmt.Errorf("%w: unknown room type %q", errors.ErrInvalidForward, forwardReq.TargetRoomType)
+	}
+	if targetRoomId == chatRoomId {
+		return nil, fmt.Errorf("%w: message is already in %v", errors.ErrInvalidForward, targetRoomId)
+	}
+
+	original, err := m.getRoomMessage(chatRoomId, messageId)
+	if err != nil {
+		return nil, err
+	}
+	if original.Metadata.MediaStatus == dto.MediaStatusPending {
+		return nil, fmt.Errorf("%w: attachments of %v are still uploading", errors.ErrInvalidForward, messageId)
+	}
+	if original.ContentType == dto.ContentTypePoll {
+		return nil, fmt.Errorf("%w: polls can not be forwarded", errors.ErrInvalidForward)
+	}
+
+	isParticipant, err := m.isRoomParticipant(roomType, chatRoomId, accessToken, refreshToken, userId)
+	if err != nil || !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", userId, chatRoomId), "error", err)
+		return nil, fmt.Errorf("%w: %v is not a participant of %v", errors.ErrPermissionDenied, userId, chatRoomId)
+	}
+	ttl, err := m.checkPost(targetRoomType, targetRoomId, userId, accessToken, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	message := original.ForwardTo(userId, targetRoomId, uint64(time.Now().UnixMilli()))
+	message.ExpiresAt = expiryOf(message.CreatedAt, ttl)
+	err = m.shareAttachments(message)
+	if err != nil {
+		return nil, err
+	}
+	err = m.messageRepository.SaveUserMessage(message)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error has occured while saving forwarded message: %v", err.Error()))
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+
+	messageWithTokens := models.MessageWithTokens{
+		Type:         dto.MessageTypeCreate,
+		Message:      *message,
+		ActorId:      userId,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(targetRoomType), bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	slog.Debug(fmt.Sprintf("Message %v forwarded to %v as %v", messageId, targetRoomId, message.Id))
+	return message, nil
+}
+
+// shareAttachments tells media-handler that a forwarded copy shares the files
+// of its original, so they outlive the original while the copy exists. It
+// runs before the copy is saved; a copy that is never saved only keeps the
+// files until its room is purged.
+func (m *MessageService) shareAttachments(message *models.Message) error {
+	if len(message.Metadata.Attachments) == 0 {
+		return nil
+	}
+	fileIds := make([]string, 0, len(message.Metadata.Attachments))
+	for _, attachment := range message.Metadata.Attachments {
+		fileIds = append(fileIds, attachment.FileId)
+	}
+	bytes, err := json.Marshal(models.MessageForwardedEvent{
+		MessageId:   message.Id,
+		ChatRoomId:  message.ChatRoomId,
+		FileIds:     fileIds,
+		ForwardedAt: message.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	err = m.messageRepository.PublishToRedisChannel("message-forwarded-channel", bytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	return nil
+}
+
+// Pin pins or unpins a message on behalf of a member whose role allows
+// pinning. Changes are published to the room like message events.
+func (m *MessageService) Pin(userId uuid.UUID, roomType models.RoomType, pinReq *dto.PinRequest, pin bool, accessToken string, refreshToken string) (*models.Message, error) {
+	chatRoomId, err := uuid.Parse(pinReq.ChatRoomId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	messageId, err := uuid.Parse(pinReq.MessageId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	message, err := m.getRoomMessage(chatRoomId, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := m.hasRoomPermission(roomType, chatRoomId, accessToken, refreshToken, userId, models.PermissionPin)
+	if err != nil || !allowed {
+		slog.Error(fmt.Sprintf("Permission denied: %v can not pin messages in %v", userId, chatRoomId), "error", err)
+		return nil, fmt.Errorf("%w: %v can not pin messages in %v", errors.ErrPermissionDenied, userId, chatRoomId)
+	}
+
+	var changed bool
+	eventType := dto.MessageTypeUnpinned
+	if pin {
+		eventType = dto.MessageTypePinned
+		message.Pin = &models.PinnedMessage{
+			ChatRoomId: chatRoomId,
+			MessageId:  messageId,
+			PinnedBy:   userId,
+			PinnedAt:   uint64(time.Now().UnixMilli()),
+		}
+		changed, err = m.messageRepository.PinMessage(message.Pin, m.maxPinsPerRoom)
+	} else {
+		changed, err = m.messageRepository.UnpinMessage(chatRoomId, messageId)
+	}
+	if err != nil {
+		if e.Is(err, errors.ErrTooManyPins) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if !changed {
+		return message, nil
+	}
+
+	messageWithTokens := models.MessageWithTokens{
+		Type:         eventType,
+		Message:      *message,
+		ActorId:      userId,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(roomType), bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	slog.Debug(fmt.Sprintf("Message %v %v published", eventType, messageId))
+	return message, nil
+}
+
+// MaxEmojiLength bounds a reaction, long enough for emoji sequences with
+// skin tones and joiners.
+const MaxEmojiLength = 32
+
+// React adds or removes a reaction of a room member to a message. When the
+// reactions changed, the message is published with its new counts.
+func (m *MessageService) React(userId uuid.UUID, roomType models.RoomType, reactionReq *dto.ReactionRequest, add bool, accessToken string, refreshToken string) (*models.Message, error) {
+	if !isEmoji(reactionReq.Emoji) {
+		return nil, fmt.Errorf("%w: %q is not an emoji", errors.ErrInvalidReaction, reactionReq.Emoji)
+	}
+	chatRoomId, err := uuid.Parse(reactionReq.ChatRoomId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	messageId, err := uuid.Parse(reactionReq.MessageId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	message, err := m.getRoomMessage(chatRoomId, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	isParticipant, err := m.isRoomParticipant(roomType, chatRoomId, accessToken, refreshToken, userId)
+	if err != nil || !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", userId, chatRoomId), "error", err)
+		return nil, fmt.Errorf("%w: %v is not a participant of %v", errors.ErrPermissionDenied, userId, chatRoomId)
+	}
+
+	reaction := &models.MessageReaction{
+		MessageId:  message.Id,
+		UserId:     userId,
+		Emoji:      reactionReq.Emoji,
+		ChatRoomId: chatRoomId,
+		CreatedAt:  uint64(time.Now().UnixMilli()),
+	}
+	var changed bool
+	if add {
+		changed, err = m.messageRepository.AddReaction(reaction)
+	} else {
+		changed, err = m.messageRepository.RemoveReaction(reaction)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if !changed {
+		return message, nil
+	}
+
+	message.Reactions, err = m.messageRepository.GetReactionCounts([]uuid.UUID{message.Id})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	messageWithTokens := models.MessageWithTokens{
+		Type:         dto.MessageTypeReaction,
+		Message:      *message,
+		ActorId:      userId,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	bytes, err := json.Marshal(messageWithTokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	err = m.messageRepository.PushToRedisQueue(m.redisQueueFor(roomType), bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrPublishMessageError, err)
+	}
+	slog.Debug(fmt.Sprintf("Reaction of %v to %v published", userId, messageId))
+	return message, nil
+}
+
+// isEmoji accepts a short sequence starting with a pictographic symbol, so
+// plain text can not be used as a reaction.
+func isEmoji(value string) bool {
+	if value == "" || len(value) > MaxEmojiLength {
+		return false
+	}
+	for i, r := range value {
+		if i == 0 && !unicode.Is(unicode.So, r) {
+			return false
+		}
+		if unicode.IsLetter(r) || unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarkRead marks every message of the room up to the requested one as read by
+// userId and notifies the senders whose messages became read.
+func (m *MessageService) MarkRead(userId uuid.UUID, roomType models.RoomType, readReq *dto.ReadRequest, accessToken string, refreshToken string) (*models.Message, error) {
+	messageId, err := uuid.Parse(readReq.MessageId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMapping, err)
+	}
+	message, err := m.messageRepository.GetMessageById(messageId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, messageId)
+		}
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if message.ChatRoomId.String() != readReq.ChatRoomId {
+		return nil, fmt.Errorf("%w: %v", errors.ErrMessageNotFound, messageId)
+	}
+
+	isParticipant, err := m.isRoomParticipant(roomType, message.ChatRoomId, accessToken, refreshToken, userId)
+	if err != nil || !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", userId, message.ChatRoomId), "error", err)
+		return nil, fmt.Errorf("%w: %v is not a participant of %v", errors.ErrPermissionDenied, userId, message.ChatRoomId)
+	}
+
+	readAt := uint64(time.Now().UnixMilli())
+	senderIds, err := m.messageRepository.MarkReadUpTo(userId, message, readAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDatabaseInternalError, err)
+	}
+	if len(senderIds) > 0 {
+		m.publishReceipt(&models.ReceiptEvent{
+			Status:      dto.ReceiptStatusRead,
+			MessageId:   message.Id,
+			ChatRoomId:  message.ChatRoomId,
+			UserId:      userId,
+			Timestamp:   readAt,
+			ReceiverIds: senderIds,
+		})
+	}
+	return message, nil
+}
+
+func (m *MessageService) isRoomParticipant(roomType models.RoomType, roomId uuid.UUID, accessToken string, refreshToken string, userId uuid.UUID) (bool, error) {
+	if roomType == models.ChannelRoomType {
+		return m.channelMgmtClient.PerformIsParticipant(roomId.String(), accessToken, refreshToken, userId.String())
+	}
+	return m.chatMgmtClient.PerformIsParticipant(roomId.String(), accessToken, refreshToken, userId.String())
+}
+
+// checkPost makes sure userId is a member of the room whose role allows
+// posting and returns the message TTL of the room. New messages are checked
+// before they are saved, so nothing the user may not post ends up in the
+// history.
+func (m *MessageService) checkPost(roomType models.RoomType, roomId uuid.UUID, userId uuid.UUID, accessToken string, refreshToken string) (uint64, error) {
+	if roomType == models.ChannelRoomType {
+		allowed, err := m.hasRoomPermission(roomType, roomId, accessToken, refreshToken, userId, models.PermissionPost)
+		if err != nil || !allowed {
+			slog.Error(fmt.Sprintf("Permission denied: %v can not post in %v", userId, roomId), "error", err)
+			return 0, fmt.Errorf("%w: %v can not post in %v", errors.ErrPermissionDenied, userId, roomId)
+		}
+		return 0, nil
+	}
+	chat, err := m.chatMgmtClient.PerformGetChat(roomId.String(), accessToken, refreshToken, userId.String())
+	if err != nil || !client.HasChatPermission(chat, userId.String(), models.PermissionPost) {
+		slog.Error(fmt.Sprintf("Permission denied: %v can not post in %v", userId, roomId), "error", err)
+		return 0, fmt.Errorf("%w: %v can not post in %v", errors.ErrPermissionDenied, userId, roomId)
+	}
+	return chat.MessageTtl, nil
+}
+
+// canPostAsService reports whether userId is a member of the room whose role
+// allows posting, together with the message TTL of the room. The room is
+// read with the service token, for work done on behalf of a user who is not
+// connected.
+func (m *MessageService) canPostAsService(roomType models.RoomType, roomId uuid.UUID, userId uuid.UUID) (bool, uint64, error) {
+	if roomType == models.ChannelRoomType {
+		channel, err := m.channelMgmtClient.PerformGetChannelAsService(roomId.String(), userId.String())
+		if err != nil {
+			return false, 0, err
+		}
+		return client.HasChannelPermission(channel, userId.String(), models.PermissionPost), 0, nil
+	}
+	chat, err := m.chatMgmtClient.PerformGetChatAsService(roomId.String(), userId.String())
+	if err != nil {
+		return false, 0, err
+	}
+	return client.HasChatPermission(chat, userId.String(), models.PermissionPost), chat.MessageTtl, nil
+}
+
+// hasRoomPermission reports whether the role of userId in the room grants
+// permission.
+func (m *MessageService) hasRoomPermission(roomType models.RoomType, roomId uuid.UUID, accessToken string, refreshToken string, userId uuid.UUID, permission string) (bool, error) {
+	if roomType == models.ChannelRoomType {
+		return m.channelMgmtClient.PerformCheckPermission(roomId.String(), accessToken, refreshToken, userId.String(), permission)
+	}
+	return m.chatMgmtClient.PerformCheckPermission(roomId.String(), accessToken, refreshToken, userId.String(), permission)
+}
+
+func (m *MessageService) redisQueueFor(roomType models.RoomType) string {
+	if roomType == models.ChannelRoomType {
+		return m.RedisQueueForChannelMessagesName
+	}
+	return m.RedisQueueForChatRoomMessagesName
 }
 
-func (m *MessageService) SubscribeToMessageChannel(channelName string) *redis.PubSub {
-	return m.messageRepository.SubscribeToRedisChannel(channelName)
+func (m *MessageService) PopFromMessageQueue(queueName string) (string, error) {
+	return m.messageRepository.PopFromRedisQueue(queueName)
 }
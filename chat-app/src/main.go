@@ -26,16 +26,16 @@ func main() {
 	database.Init(cfg)
 	db := database.DB
 	authClient := client.NewAuthClient(cfg)
-	messageRepository := repository.New(db, redisClient)
-	messageHistoryService := service.NewMessageHistoryService(messageRepository)
-	messageService := service.NewMessageService(messageRepository)
-	messageHistoryController := controller.NewMessageHistoryController(messageHistoryService)
-
 	channelMgmtClient := client.NewChanMgmtClient(cfg)
 	chatMgmtClient := client.NewChatMgmtClient(cfg)
+	messageRepository := repository.New(db, redisClient)
+	messageHistoryService := service.NewMessageHistoryService(messageRepository)
+	messageService := service.NewMessageService(messageRepository, chatMgmtClient, channelMgmtClient, cfg.App.InstanceId, cfg.App.MaxPinsPerRoom)
+	messageHistoryController := controller.NewMessageHistoryController(messageHistoryService, authClient, channelMgmtClient, chatMgmtClient)
 
 	webSocketController := controller.NewWebsocketController(messageService, authClient, channelMgmtClient, chatMgmtClient)
-	server := server.NewHttpServer(messageHistoryController, webSocketController)
+	scheduledMessageController := controller.NewScheduledMessageController(messageService, authClient, channelMgmtClient, chatMgmtClient)
+	server := server.NewHttpServer(messageHistoryController, webSocketController, scheduledMessageController)
 	app := app.New(server, cfg)
 	go app.MustRun()
 	stop := make(chan os.Signal, 1)
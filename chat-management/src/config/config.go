@@ -9,11 +9,15 @@ type Config struct {
 	Auth     AuthConfig
 	App      AppConfig
 	Database DatabaseConfig
+	Redis    RedisConfig
 }
 
 type AuthConfig struct {
 	AuthHost string `env:"AUTH_APP_HOST"`
 	AuthPort string `env:"AUTH_APP_PORT"`
+	// ServiceToken is shared with chat-app, which uses it to read chats on
+	// behalf of users who are not connected.
+	ServiceToken string `env:"AUTH_SERVICE_TOKEN"`
 }
 
 type AppConfig struct {
@@ -30,6 +34,13 @@ type DatabaseConfig struct {
 	SslMode      string `env:"DB_SSL_MODE"`
 }
 
+type RedisConfig struct {
+	Db        int    `env:"REDIS_DB"`
+	Password  string `env:"REDIS_PASSWORD"`
+	Host      string `env:"REDIS_HOST"`
+	InnerPort int    `env:"REDIS_INNER_PORT"`
+}
+
 func MustLoad() *Config {
 	err := godotenv.Load()
 	if err != nil {
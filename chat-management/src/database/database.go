@@ -34,15 +34,72 @@ func Init(cfg *config.Config) {
 		str,
 	)
 	if err != nil {
-		slog.Error("Error has occured while connecting to DB", err)
+		slog.Error("Error has occured while connecting to DB", "error", err.Error())
 		panic(err)
 	}
 
-	db.AutoMigrate(&models.Chat{}, &models.UserChat{}, &models.Admin{})
+	db.AutoMigrate(&models.Chat{}, &models.UserChat{}, &models.DirectChat{}, &models.Invite{})
+	migrateAdmins(db)
+	migrateMemberships(db)
 	DB = db
 	slog.Info("Connected to DB")
 }
 
+// migrateAdmins folds the legacy admins table into member roles: creators
+// become owners and admins keep the admin role. The table is dropped after.
+func migrateAdmins(db *gorm.DB) {
+	if !db.HasTable("admins") {
+		return
+	}
+	slog.Info("Migrating chat admins to member roles")
+	tx := db.Begin()
+	err := tx.Exec(
+		`UPDATE user_chats SET role = ? FROM admins
+		WHERE admins.chat_id = user_chats.chat_id AND admins.user_id = user_chats.user_id
+		AND admins.deleted_at IS NULL`,
+		models.RoleAdmin,
+	).Error
+	if err == nil {
+		err = tx.Exec(
+			`UPDATE user_chats SET role = ? FROM chats
+			WHERE chats.id = user_chats.chat_id AND chats.creator_id = user_chats.user_id
+			AND chats.is_direct = false`,
+			models.RoleOwner,
+		).Error
+	}
+	if err == nil {
+		err = tx.DropTable("admins").Error
+	}
+	if err != nil {
+		tx.Rollback()
+		slog.Error("Error has occured while migrating admins", "error", err.Error())
+		panic(err)
+	}
+	tx.Commit()
+}
+
+// migrateMemberships makes a user a member of a chat at most once. Left
+// memberships are soft-deleted and do not count. Duplicates that concurrent
+// joins left behind are removed first, the oldest membership stays.
+func migrateMemberships(db *gorm.DB) {
+	err := db.Exec(
+		`UPDATE user_chats SET deleted_at = NOW()
+		WHERE deleted_at IS NULL AND id NOT IN (
+			SELECT MIN(id) FROM user_chats WHERE deleted_at IS NULL GROUP BY chat_id, user_id
+		)`,
+	).Error
+	if err == nil {
+		err = db.Exec(
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_user_chats_chat_user
+			ON user_chats (chat_id, user_id) WHERE deleted_at IS NULL`,
+		).Error
+	}
+	if err != nil {
+		slog.Error("Error has occured while migrating memberships", "error", err.Error())
+		panic(err)
+	}
+}
+
 func Close() {
 	slog.Info("Disconneting from DB")
 	DB.Close()
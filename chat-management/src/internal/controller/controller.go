@@ -9,6 +9,8 @@ import (
 	"example.com/chat-management/src/internal/client"
 	"example.com/chat-management/src/internal/dto"
 	"example.com/chat-management/src/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ChatManagementController struct {
@@ -58,6 +60,41 @@ func (c *ChatManagementController) CreateChatHandler(w http.ResponseWriter, r *h
 	w.Write(chatResp)
 }
 
+func (c *ChatManagementController) CreateDirectChatHandler(w http.ResponseWriter, r *http.Request) {
+	var directReq dto.CreateDirectChatRequest
+	err := json.NewDecoder(r.Body).Decode(&directReq)
+	if err != nil {
+		slog.Error("Failed to decode request", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, directReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	chat, err := c.service.CreateDirectChat(&directReq)
+	if err != nil {
+		slog.Error("Failed to create direct chat", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	chatResp, err := json.Marshal(chat)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(chatResp)
+}
+
 func (c *ChatManagementController) DeleteChatHandler(w http.ResponseWriter, r *http.Request) {
 	var chatReq dto.DeleteChatRequest
 	err := json.NewDecoder(r.Body).Decode(&chatReq)
@@ -75,7 +112,7 @@ func (c *ChatManagementController) DeleteChatHandler(w http.ResponseWriter, r *h
 
 	err = c.service.DeleteChat(&chatReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -133,7 +170,7 @@ func (c *ChatManagementController) UpdateChatHandler(w http.ResponseWriter, r *h
 
 	err = c.service.UpdateChat(&chatReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -208,19 +245,10 @@ func (c *ChatManagementController) InviteUserHandler(w http.ResponseWriter, r *h
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	isAdminReq := dto.IsAdminRequest{
-		ChatId: inviteReq.ChatId,
-		UserId: inviteReq.UserId,
-	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", isAdminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
-		return
-	}
 
 	err = c.service.InviteUser(&inviteReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -243,19 +271,10 @@ func (c *ChatManagementController) KickUserHandler(w http.ResponseWriter, r *htt
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	isAdminReq := dto.IsAdminRequest{
-		ChatId: kickReq.ChatId,
-		UserId: kickReq.UserId,
-	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", isAdminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
-		return
-	}
 
 	err = c.service.KickUser(&kickReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -278,19 +297,10 @@ func (c *ChatManagementController) MakeAdminHandler(w http.ResponseWriter, r *ht
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	isAdminReq := dto.IsAdminRequest{
-		ChatId: adminReq.ChatId,
-		UserId: adminReq.UserId,
-	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", isAdminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
-		return
-	}
 
 	err = c.service.MakeAdmin(&adminReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -313,23 +323,272 @@ func (c *ChatManagementController) DeleteAdminHandler(w http.ResponseWriter, r *
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	isAdminReq := dto.IsAdminRequest{
-		ChatId: adminReq.ChatId,
-		UserId: adminReq.UserId,
+
+	err = c.service.DeleteAdmin(&adminReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
 	}
-	if isAdmin, err := c.service.IsAdmin(&isAdminReq); !isAdmin || err != nil {
-		slog.Error(fmt.Sprintf("Permission denied: %v not an admin", isAdminReq.UserId))
-		http.Error(w, "permission denied", http.StatusForbidden)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+}
+
+func (c *ChatManagementController) ListUserChatsHandler(w http.ResponseWriter, r *http.Request) {
+	var listReq dto.ListUserChatsRequest
+	err := json.NewDecoder(r.Body).Decode(&listReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	err = c.service.DeleteAdmin(&adminReq)
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, listReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	chats, err := c.service.ListUserChats(&listReq)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	chatsResp, err := json.Marshal(chats)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(chatsResp)
+}
+
+func (c *ChatManagementController) SetMemberRoleHandler(w http.ResponseWriter, r *http.Request) {
+	var roleReq dto.SetMemberRoleRequest
+	err := json.NewDecoder(r.Body).Decode(&roleReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, roleReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	err = c.service.SetMemberRole(&roleReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+}
+
+func (c *ChatManagementController) TransferOwnershipHandler(w http.ResponseWriter, r *http.Request) {
+	var transferReq dto.TransferOwnershipRequest
+	err := json.NewDecoder(r.Body).Decode(&transferReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, transferReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	err = c.service.TransferOwnership(&transferReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
 	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
 }
+
+func (c *ChatManagementController) SetMessageTTLHandler(w http.ResponseWriter, r *http.Request) {
+	var ttlReq dto.SetMessageTTLRequest
+	err := json.NewDecoder(r.Body).Decode(&ttlReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, ttlReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	err = c.service.SetMessageTTL(&ttlReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+}
+
+func (c *ChatManagementController) CreateInviteHandler(w http.ResponseWriter, r *http.Request) {
+	var inviteReq dto.CreateInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&inviteReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, inviteReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	invite, err := c.service.CreateInvite(&inviteReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(invite)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChatManagementController) RevokeInviteHandler(w http.ResponseWriter, r *http.Request) {
+	var revokeReq dto.RevokeInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&revokeReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, revokeReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	invite, err := c.service.RevokeInvite(&revokeReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(invite)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChatManagementController) ListInvitesHandler(w http.ResponseWriter, r *http.Request) {
+	var listReq dto.ListInvitesRequest
+	err := json.NewDecoder(r.Body).Decode(&listReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, listReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	invites, err := c.service.ListInvites(&listReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(invites)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+func (c *ChatManagementController) JoinByInviteHandler(w http.ResponseWriter, r *http.Request) {
+	var joinReq dto.JoinByInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&joinReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	authResp, err := c.authClient.PerformAuthorize(r.Context(), r, joinReq.UserId.String())
+	if err != nil {
+		slog.Error("Authorization error", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	chatId, err := c.service.JoinByInvite(&joinReq)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp, err := json.Marshal(dto.ChatResponse{ChatId: chatId.String()})
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Set-Cookie", fmt.Sprintf("Authorization=%s; HttpOnly", authResp.AccessToken))
+	w.Header().Add("Set-Cookie", fmt.Sprintf("X-Refresh-Token=%s; HttpOnly", authResp.RefreshToken))
+	w.Write(resp)
+}
+
+// httpStatus maps the gRPC status returned by the service to an HTTP status.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Internal:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
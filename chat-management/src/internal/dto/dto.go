@@ -9,6 +9,12 @@ type CreateChatRequest struct {
 	Name        string
 	Description string
 	CreatorId   uuid.UUID
+	IsPrivate   bool
+}
+
+type CreateDirectChatRequest struct {
+	UserId uuid.UUID
+	PeerId uuid.UUID
 }
 
 type DeleteChatRequest struct {
@@ -66,7 +72,82 @@ type GetChatRequest struct {
 }
 
 type GetChatResponse struct {
-	Chat   *models.Chat
-	Users  []string
-	Admins []string
+	Chat    *models.Chat
+	Users   []string
+	Admins  []string
+	Members []ChatMember
+}
+
+type ChatMember struct {
+	UserId      uuid.UUID
+	Role        models.Role
+	Permissions []models.Permission
+}
+
+type SetMemberRoleRequest struct {
+	ChatId       uuid.UUID
+	UserId       uuid.UUID
+	TargetUserId uuid.UUID
+	Role         models.Role
+}
+
+type SetMessageTTLRequest struct {
+	ChatId     uuid.UUID
+	UserId     uuid.UUID
+	MessageTTL uint64
+}
+
+type TransferOwnershipRequest struct {
+	ChatId     uuid.UUID
+	UserId     uuid.UUID
+	NewOwnerId uuid.UUID
+}
+
+type CheckPermissionRequest struct {
+	ChatId     uuid.UUID
+	UserId     uuid.UUID
+	Permission models.Permission
+}
+
+type ListUserChatsRequest struct {
+	UserId    uuid.UUID
+	Limit     int
+	PageToken string
+}
+
+type ListUserChatsResponse struct {
+	Chats         []*GetChatResponse
+	NextPageToken string
+}
+
+type CreateInviteRequest struct {
+	ChatId    uuid.UUID
+	UserId    uuid.UUID
+	ExpiresAt uint64
+	MaxUses   int
+}
+
+type RevokeInviteRequest struct {
+	Token  string
+	UserId uuid.UUID
+}
+
+type ListInvitesRequest struct {
+	ChatId uuid.UUID
+	UserId uuid.UUID
+}
+
+type JoinByInviteRequest struct {
+	Token  string
+	UserId uuid.UUID
+}
+
+type InviteResponse struct {
+	Token     string
+	ChatId    uuid.UUID
+	CreatorId uuid.UUID
+	ExpiresAt uint64
+	MaxUses   int
+	Uses      int
+	Revoked   bool
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"slices"
+
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 )
@@ -10,16 +12,138 @@ type Chat struct {
 	Name        string
 	Description string
 	CreatorId   uuid.UUID `gorm:"type:uuid"`
+	IsDirect    bool
+	// IsPrivate chats can only be joined through an invite.
+	IsPrivate bool
+	// MessageTTL is how many seconds messages stay in the chat before they
+	// disappear. Zero keeps them forever.
+	MessageTTL uint64
 }
 
-type UserChat struct {
+// MaxMessageTTL is the longest lifetime that can be set for messages.
+const MaxMessageTTL = 365 * 24 * 60 * 60
+
+// DirectChat maps an unordered user pair to its one-to-one chat. FirstUserId
+// is always the smaller id so that each pair has a single row.
+type DirectChat struct {
+	ChatId       uuid.UUID `gorm:"type:uuid;primary_key"`
+	FirstUserId  uuid.UUID `gorm:"type:uuid;unique_index:idx_direct_chats_pair"`
+	SecondUserId uuid.UUID `gorm:"type:uuid;unique_index:idx_direct_chats_pair"`
+}
+
+// Invite is a shareable link to join a chat. ExpiresAt and MaxUses are
+// unlimited when zero.
+type Invite struct {
 	gorm.Model
-	ChatId uuid.UUID `gorm:"type:uuid"`
-	UserId uuid.UUID `gorm:"type:uuid"`
+	Token     string    `gorm:"unique_index"`
+	ChatId    uuid.UUID `gorm:"type:uuid;index"`
+	CreatorId uuid.UUID `gorm:"type:uuid"`
+	ExpiresAt uint64
+	MaxUses   int
+	Uses      int
+	Revoked   bool
+}
+
+func (i *Invite) IsExpired(now uint64) bool {
+	return i.ExpiresAt != 0 && now >= i.ExpiresAt
 }
 
-type Admin struct {
+func (i *Invite) IsExhausted() bool {
+	return i.MaxUses != 0 && i.Uses >= i.MaxUses
+}
+
+type UserChat struct {
 	gorm.Model
 	ChatId uuid.UUID `gorm:"type:uuid"`
 	UserId uuid.UUID `gorm:"type:uuid"`
+	Role   Role      `gorm:"default:'member'"`
+}
+
+// Role is the rank of a member inside a chat. Roles are ordered, a member can
+// only manage members ranked below them.
+type Role string
+
+const (
+	RoleOwner     Role = "owner"
+	RoleAdmin     Role = "admin"
+	RoleModerator Role = "moderator"
+	RoleMember    Role = "member"
+	RoleReadOnly  Role = "read_only"
+)
+
+type Permission string
+
+const (
+	PermissionPost           Permission = "post"
+	PermissionInvite         Permission = "invite"
+	PermissionKick           Permission = "kick"
+	PermissionEditInfo       Permission = "edit_info"
+	PermissionPin            Permission = "pin"
+	PermissionEditMessages   Permission = "edit_messages"
+	PermissionDeleteMessages Permission = "delete_messages"
+	PermissionManageRoles    Permission = "manage_roles"
+	PermissionDeleteChat     Permission = "delete_chat"
+)
+
+// RolePermissions is the permission matrix of chat roles.
+var RolePermissions = map[Role][]Permission{
+	RoleOwner: {
+		PermissionPost, PermissionInvite, PermissionKick, PermissionEditInfo, PermissionPin,
+		PermissionEditMessages, PermissionDeleteMessages, PermissionManageRoles, PermissionDeleteChat,
+	},
+	RoleAdmin: {
+		PermissionPost, PermissionInvite, PermissionKick, PermissionEditInfo, PermissionPin,
+		PermissionEditMessages, PermissionDeleteMessages, PermissionManageRoles,
+	},
+	RoleModerator: {PermissionPost, PermissionInvite, PermissionKick, PermissionPin, PermissionDeleteMessages},
+	RoleMember:    {PermissionPost, PermissionInvite},
+	RoleReadOnly:  {},
+}
+
+var roleRanks = map[Role]int{
+	RoleOwner:     4,
+	RoleAdmin:     3,
+	RoleModerator: 2,
+	RoleMember:    1,
+	RoleReadOnly:  0,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(RolePermissions[r], permission)
+}
+
+// Outranks reports whether r is ranked strictly above other.
+func (r Role) Outranks(other Role) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+func (r Role) IsAdmin() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// Successor picks the member that inherits the ownership of a chat: the
+// highest ranked one, the longest standing first. members must be ordered
+// by join time.
+func Successor(members []UserChat) *UserChat {
+	var successor *UserChat
+	for i := range members {
+		if successor == nil || members[i].Role.Outranks(successor.Role) {
+			successor = &members[i]
+		}
+	}
+	return successor
+}
+
+// ChatDeletedEvent is published once a chat and its memberships are gone so
+// that chat-app and media-handler purge its messages and attachments.
+// DeletedAt is in unix milliseconds.
+type ChatDeletedEvent struct {
+	ChatId    uuid.UUID `json:"chatId"`
+	DeletedBy uuid.UUID `json:"deletedBy"`
+	DeletedAt uint64    `json:"deletedAt"`
 }
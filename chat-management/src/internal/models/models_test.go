@@ -0,0 +1,136 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRoleCan(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		want       bool
+	}{
+		{role: RoleOwner, permission: PermissionDeleteChat, want: true},
+		{role: RoleAdmin, permission: PermissionDeleteChat, want: false},
+		{role: RoleAdmin, permission: PermissionManageRoles, want: true},
+		{role: RoleAdmin, permission: PermissionEditMessages, want: true},
+		{role: RoleModerator, permission: PermissionDeleteMessages, want: true},
+		{role: RoleModerator, permission: PermissionEditMessages, want: false},
+		{role: RoleModerator, permission: PermissionManageRoles, want: false},
+		{role: RoleMember, permission: PermissionPost, want: true},
+		{role: RoleMember, permission: PermissionKick, want: false},
+		{role: RoleReadOnly, permission: PermissionPost, want: false},
+		{role: Role("guest"), permission: PermissionPost, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.permission), func(t *testing.T) {
+			if got := tt.role.Can(tt.permission); got != tt.want {
+				t.Fatalf("%v.Can(%v) = %v, want %v", tt.role, tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleOutranks(t *testing.T) {
+	tests := []struct {
+		role  Role
+		other Role
+		want  bool
+	}{
+		{role: RoleOwner, other: RoleAdmin, want: true},
+		{role: RoleAdmin, other: RoleModerator, want: true},
+		{role: RoleModerator, other: RoleMember, want: true},
+		{role: RoleMember, other: RoleReadOnly, want: true},
+		{role: RoleAdmin, other: RoleAdmin, want: false},
+		{role: RoleMember, other: RoleModerator, want: false},
+		{role: RoleReadOnly, other: RoleOwner, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.other), func(t *testing.T) {
+			if got := tt.role.Outranks(tt.other); got != tt.want {
+				t.Fatalf("%v.Outranks(%v) = %v, want %v", tt.role, tt.other, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuccessor(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []Role
+		// want is the position of the successor in roles, -1 for none.
+		want int
+	}{
+		{name: "no member left", roles: nil, want: -1},
+		{name: "single member", roles: []Role{RoleReadOnly}, want: 0},
+		{name: "admin over older members", roles: []Role{RoleMember, RoleModerator, RoleAdmin}, want: 2},
+		{name: "oldest of equal rank", roles: []Role{RoleMember, RoleAdmin, RoleAdmin}, want: 1},
+		{name: "only members", roles: []Role{RoleMember, RoleMember}, want: 0},
+		{name: "member over read only", roles: []Role{RoleReadOnly, RoleMember}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := make([]UserChat, len(tt.roles))
+			for i, role := range tt.roles {
+				members[i] = UserChat{UserId: uuid.New(), Role: role}
+			}
+			got := Successor(members)
+			if tt.want < 0 {
+				if got != nil {
+					t.Fatalf("Successor() = %v, want none", got.UserId)
+				}
+				return
+			}
+			if got == nil || got.UserId != members[tt.want].UserId {
+				t.Fatalf("Successor() = %v, want member %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInviteIsExpired(t *testing.T) {
+	const now = 1700000000000
+	tests := []struct {
+		name      string
+		expiresAt uint64
+		want      bool
+	}{
+		{name: "never expires", expiresAt: 0, want: false},
+		{name: "expires later", expiresAt: now + 1, want: false},
+		{name: "expires now", expiresAt: now, want: true},
+		{name: "expired", expiresAt: now - 1, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invite := &Invite{ExpiresAt: tt.expiresAt}
+			if got := invite.IsExpired(now); got != tt.want {
+				t.Fatalf("IsExpired(%d) with ExpiresAt %d = %v, want %v", now, tt.expiresAt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInviteIsExhausted(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxUses int
+		uses    int
+		want    bool
+	}{
+		{name: "unlimited", maxUses: 0, uses: 1000, want: false},
+		{name: "unused", maxUses: 1, uses: 0, want: false},
+		{name: "uses left", maxUses: 5, uses: 4, want: false},
+		{name: "used up", maxUses: 5, uses: 5, want: true},
+		{name: "overused", maxUses: 5, uses: 6, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invite := &Invite{MaxUses: tt.maxUses, Uses: tt.uses}
+			if got := invite.IsExhausted(); got != tt.want {
+				t.Fatalf("IsExhausted() with %d of %d uses = %v, want %v", tt.uses, tt.maxUses, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,19 +1,32 @@
 package repository
 
 import (
+	"context"
+	"errors"
+
 	"example.com/chat-management/src/internal/dto"
 	"example.com/chat-management/src/internal/models"
+	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type ChatRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewChatRepository(db *gorm.DB, redis *redis.Client) *ChatRepository {
+	return &ChatRepository{db: db, redis: redis}
 }
 
-func NewChatRepository(db *gorm.DB) *ChatRepository {
-	return &ChatRepository{db: db}
+// PublishInChatDeletedChannel does nothing while Redis is not configured.
+func (r *ChatRepository) PublishInChatDeletedChannel(message interface{}) error {
+	if r.redis == nil {
+		return nil
+	}
+	return r.redis.Publish(context.Background(), "chat-deleted-channel", message).Err()
 }
 
 func (r *ChatRepository) FindById(chatId uuid.UUID) (*models.Chat, error) {
@@ -26,8 +39,32 @@ func (r *ChatRepository) SaveChat(chat *models.Chat) error {
 	return r.db.Create(chat).Error
 }
 
+// DeleteChat removes the chat together with its memberships, invites and
+// direct chat pair in one transaction.
 func (r *ChatRepository) DeleteChat(chatId uuid.UUID) error {
-	return r.db.Where("id = ?", chatId).Delete(&models.Chat{}).Error
+	tx := r.db.Begin()
+	if err := tx.Unscoped().Where("chat_id = ?", chatId).Delete(&models.UserChat{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("chat_id = ?", chatId).Delete(&models.DirectChat{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Unscoped().Where("chat_id = ?", chatId).Delete(&models.Invite{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	result := tx.Where("id = ?", chatId).Delete(&models.Chat{})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+	return tx.Commit().Error
 }
 
 func (r *ChatRepository) UpdateChat(request *dto.UpdateChatRequest) error {
@@ -37,62 +74,224 @@ func (r *ChatRepository) UpdateChat(request *dto.UpdateChatRequest) error {
 	}).Error
 }
 
+func (r *ChatRepository) SetMessageTTL(chatId uuid.UUID, messageTTL uint64) error {
+	return r.db.Model(&models.Chat{}).Where("id = ?", chatId).Update("message_ttl", messageTTL).Error
+}
+
+func (r *ChatRepository) FindDirectChat(firstUserId uuid.UUID, secondUserId uuid.UUID) (*models.DirectChat, error) {
+	var directChat models.DirectChat
+	err := r.db.Where("first_user_id = ? AND second_user_id = ?", firstUserId, secondUserId).First(&directChat).Error
+	return &directChat, err
+}
+
+// SaveDirectChat creates the chat, its pair mapping and both memberships in
+// one transaction.
+func (r *ChatRepository) SaveDirectChat(chat *models.Chat, directChat *models.DirectChat) error {
+	tx := r.db.Begin()
+	if err := tx.Create(chat).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(directChat).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, userId := range []uuid.UUID{directChat.FirstUserId, directChat.SecondUserId} {
+		if err := tx.Create(&models.UserChat{ChatId: chat.Id, UserId: userId, Role: models.RoleMember}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// AddUserToChat fails with an error IsUniqueViolation recognizes when the
+// user is already a member.
 func (r *ChatRepository) AddUserToChat(user *models.UserChat) error {
 	return r.db.Create(&user).Error
 }
 
-func (r *ChatRepository) RemoveUserFromChat(user *models.UserChat) error {
-	return r.db.Where("chat_id = ? AND user_id = ?", user.ChatId, user.UserId).Delete(&models.UserChat{}).Error
+// IsUniqueViolation reports whether err comes from a unique index, like the
+// one that keeps a user from joining a chat twice.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
 }
 
-func (r *ChatRepository) AddAdmin(admin *models.Admin) error {
-	return r.db.Create(&admin).Error
+// RemoveUserFromChat removes the membership. When the owner leaves, the
+// successor among the remaining members becomes the owner. It returns the
+// promoted user, uuid.Nil when nobody was promoted, and how many members
+// remain.
+func (r *ChatRepository) RemoveUserFromChat(user *models.UserChat) (uuid.UUID, int, error) {
+	tx := r.db.Begin()
+	// Locking every membership serializes concurrent leaves of the same chat.
+	var members []models.UserChat
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("chat_id = ?", user.ChatId).Order("id").Find(&members).Error
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, 0, err
+	}
+	var leaving *models.UserChat
+	remaining := make([]models.UserChat, 0, len(members))
+	for i := range members {
+		if members[i].UserId == user.UserId {
+			leaving = &members[i]
+			continue
+		}
+		remaining = append(remaining, members[i])
+	}
+	if leaving == nil {
+		tx.Rollback()
+		return uuid.Nil, 0, gorm.ErrRecordNotFound
+	}
+
+	err = tx.Where("chat_id = ? AND user_id = ?", user.ChatId, user.UserId).Delete(&models.UserChat{}).Error
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, 0, err
+	}
+	promoted := uuid.Nil
+	if leaving.Role == models.RoleOwner && len(remaining) > 0 {
+		successor := models.Successor(remaining)
+		err = tx.Model(&models.UserChat{}).Where("id = ?", successor.ID).Update("role", models.RoleOwner).Error
+		if err != nil {
+			tx.Rollback()
+			return uuid.Nil, 0, err
+		}
+		promoted = successor.UserId
+	}
+	if err := tx.Commit().Error; err != nil {
+		return uuid.Nil, 0, err
+	}
+	return promoted, len(remaining), nil
 }
 
-func (r *ChatRepository) RemoveAdmin(admin *models.Admin) error {
-	return r.db.Where("chat_id = ? AND user_id = ?", admin.ChatId, admin.UserId).Delete(&models.Admin{}).Error
+// TransferOwnership makes newOwnerId the owner and demotes the current owner
+// to admin in one transaction.
+func (r *ChatRepository) TransferOwnership(chatId uuid.UUID, ownerId uuid.UUID, newOwnerId uuid.UUID) error {
+	tx := r.db.Begin()
+	result := tx.Model(&models.UserChat{}).
+		Where("chat_id = ? AND user_id = ? AND role = ?", chatId, ownerId, models.RoleOwner).
+		Update("role", models.RoleAdmin)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+	result = tx.Model(&models.UserChat{}).Where("chat_id = ? AND user_id = ?", chatId, newOwnerId).Update("role", models.RoleOwner)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+	return tx.Commit().Error
 }
 
-func (r *ChatRepository) IsAdmin(admin *models.Admin) (bool, error) {
-	err := r.db.Where("chat_id = ? AND user_id = ?", admin.ChatId, admin.UserId).First(&admin).Error
-	if err != nil {
-		return false, err
+// GetMemberRole returns gorm.ErrRecordNotFound when the user is not a member.
+func (r *ChatRepository) GetMemberRole(chatId uuid.UUID, userId uuid.UUID) (models.Role, error) {
+	var userChat models.UserChat
+	err := r.db.Where("chat_id = ? AND user_id = ?", chatId, userId).First(&userChat).Error
+	return userChat.Role, err
+}
+
+func (r *ChatRepository) SetMemberRole(chatId uuid.UUID, userId uuid.UUID, role models.Role) error {
+	result := r.db.Model(&models.UserChat{}).Where("chat_id = ? AND user_id = ?", chatId, userId).Update("role", role)
+	if result.Error != nil {
+		return result.Error
 	}
-	return true, nil
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ChatRepository) GetChatMembers(chatId uuid.UUID) ([]models.UserChat, error) {
+	var userChats []models.UserChat
+	err := r.db.Where("chat_id = ?", chatId).Order("id").Find(&userChats).Error
+	return userChats, err
+}
+
+// GetUserChats returns up to limit memberships of the user whose id is
+// greater than afterId, in membership order.
+func (r *ChatRepository) GetUserChats(userId uuid.UUID, afterId uint, limit int) ([]models.UserChat, error) {
+	var userChats []models.UserChat
+	err := r.db.Where("user_id = ? AND id > ?", userId, afterId).Order("id").Limit(limit).Find(&userChats).Error
+	return userChats, err
+}
+
+func (r *ChatRepository) FindByIds(chatIds []uuid.UUID) ([]models.Chat, error) {
+	var chats []models.Chat
+	err := r.db.Where("id IN (?)", chatIds).Find(&chats).Error
+	return chats, err
 }
 
-func (r *ChatRepository) GetChatUsers(chatID uuid.UUID) ([]string, error) {
+// GetUsersOfChats returns the participants of every given chat in one query.
+func (r *ChatRepository) GetUsersOfChats(chatIds []uuid.UUID) (map[uuid.UUID][]string, error) {
 	var userChats []models.UserChat
-	err := r.db.Where("chat_id = ?", chatID).Find(&userChats).Error
+	err := r.db.Where("chat_id IN (?)", chatIds).Find(&userChats).Error
 	if err != nil {
 		return nil, err
 	}
 
-	if len(userChats) == 0 {
-		return nil, gorm.ErrRecordNotFound
+	users := make(map[uuid.UUID][]string, len(chatIds))
+	for _, uc := range userChats {
+		users[uc.ChatId] = append(users[uc.ChatId], uc.UserId.String())
 	}
+	return users, nil
+}
 
-	userIDs := make([]string, len(userChats))
-	for i, uc := range userChats {
-		userIDs[i] = uc.UserId.String()
-	}
-	return userIDs, nil
+func (r *ChatRepository) SaveInvite(invite *models.Invite) error {
+	return r.db.Create(invite).Error
+}
+
+func (r *ChatRepository) FindInvite(token string) (*models.Invite, error) {
+	var invite models.Invite
+	err := r.db.Where("token = ?", token).First(&invite).Error
+	return &invite, err
 }
 
-func (r *ChatRepository) GetChatAdmins(chatId uuid.UUID) ([]string, error) {
-	var admins []models.Admin
-	err := r.db.Where("chat_id = ?", chatId).Find(&admins).Error
+func (r *ChatRepository) GetChatInvites(chatId uuid.UUID) ([]models.Invite, error) {
+	var invites []models.Invite
+	err := r.db.Where("chat_id = ?", chatId).Order("id").Find(&invites).Error
+	return invites, err
+}
+
+func (r *ChatRepository) RevokeInvite(invite *models.Invite) error {
+	err := r.db.Model(&models.Invite{}).Where("id = ?", invite.ID).Update("revoked", true).Error
 	if err != nil {
-		return nil, err
+		return err
 	}
+	invite.Revoked = true
+	return nil
+}
 
-	if len(admins) == 0 {
-		return nil, gorm.ErrRecordNotFound
+// JoinByInvite consumes one use of the invite and adds the membership in one
+// transaction. The use is only counted while the invite is still valid at
+// now, otherwise gorm.ErrRecordNotFound is returned.
+func (r *ChatRepository) JoinByInvite(invite *models.Invite, user *models.UserChat, now uint64) error {
+	tx := r.db.Begin()
+	result := tx.Model(&models.Invite{}).
+		Where("id = ? AND revoked = false", invite.ID).
+		Where("expires_at = 0 OR expires_at > ?", now).
+		Where("max_uses = 0 OR uses < max_uses").
+		Update("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
 	}
-
-	userIds := make([]string, len(admins))
-	for i, a := range admins {
-		userIds[i] = a.UserId.String()
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+	if err := tx.Create(user).Error; err != nil {
+		tx.Rollback()
+		return err
 	}
-	return userIds, nil
+	return tx.Commit().Error
 }
@@ -11,6 +11,7 @@ import (
 	"example.com/chat-management/src/internal/client"
 	"example.com/chat-management/src/internal/controller"
 	"example.com/chat-management/src/internal/dto"
+	"example.com/chat-management/src/internal/models"
 	"example.com/chat-management/src/internal/service"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
@@ -37,6 +38,15 @@ func (h *HttpServer) StartServer() {
 	http.HandleFunc("POST /admin", h.chatMgmtController.MakeAdminHandler)
 	http.HandleFunc("DELETE /admin", h.chatMgmtController.DeleteAdminHandler)
 	http.HandleFunc("GET /chat", h.chatMgmtController.GetChatHandler)
+	http.HandleFunc("POST /direct", h.chatMgmtController.CreateDirectChatHandler)
+	http.HandleFunc("GET /chats", h.chatMgmtController.ListUserChatsHandler)
+	http.HandleFunc("PUT /role", h.chatMgmtController.SetMemberRoleHandler)
+	http.HandleFunc("POST /owner", h.chatMgmtController.TransferOwnershipHandler)
+	http.HandleFunc("POST /invites", h.chatMgmtController.CreateInviteHandler)
+	http.HandleFunc("DELETE /invites", h.chatMgmtController.RevokeInviteHandler)
+	http.HandleFunc("GET /invites", h.chatMgmtController.ListInvitesHandler)
+	http.HandleFunc("POST /join/invite", h.chatMgmtController.JoinByInviteHandler)
+	http.HandleFunc("PUT /ttl", h.chatMgmtController.SetMessageTTLHandler)
 }
 
 type GRPCServer struct {
@@ -80,6 +90,7 @@ func (s *GRPCServer) CreateChat(ctx context.Context, req *chatMgmt.CreateChatReq
 		Name:        req.Name,
 		Description: req.Description,
 		CreatorId:   creatorId,
+		IsPrivate:   req.IsPrivate,
 	}
 	chatResponse, err := s.service.CreateChat(createReq)
 	if err != nil {
@@ -102,6 +113,55 @@ func (s *GRPCServer) CreateChat(ctx context.Context, req *chatMgmt.CreateChatReq
 		ParticipantsIds: response.Users,
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
+	}, nil
+}
+
+func (s *GRPCServer) CreateDirectChat(ctx context.Context, req *chatMgmt.CreateDirectChatRequest) (*chatMgmt.ChatRoomResponse, error) {
+	slog.Info("Create direct chat controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	peerId, err := uuid.Parse(req.PeerId)
+	if err != nil {
+		slog.Error("Invalid peer Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	createReq := &dto.CreateDirectChatRequest{
+		UserId: userId,
+		PeerId: peerId,
+	}
+	chatResponse, err := s.service.CreateDirectChat(createReq)
+	if err != nil {
+		slog.Error("CreateDirectChat error", "error", err.Error())
+		return nil, err
+	}
+
+	getReq := &dto.GetChatRequest{
+		ChatId: uuid.MustParse(chatResponse.ChatId),
+	}
+	response, err := s.service.GetChat(getReq)
+	if err != nil {
+		slog.Error("GetChat error", "error", err.Error())
+		return nil, err
+	}
+	return &chatMgmt.ChatRoomResponse{
+		ChatId:          response.Chat.Id.String(),
+		CreatorId:       response.Chat.CreatorId.String(),
+		ParticipantsIds: response.Users,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 	}, nil
 }
 
@@ -181,6 +241,9 @@ func (s *GRPCServer) UpdateChat(ctx context.Context, req *chatMgmt.UpdateChatReq
 		ParticipantsIds: response.Users,
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 	}, nil
 }
 
@@ -228,6 +291,9 @@ func (s *GRPCServer) JoinChat(ctx context.Context, req *chatMgmt.JoinChatRequest
 		ParticipantsIds: response.Users,
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 	}, nil
 }
 
@@ -266,8 +332,9 @@ func (s *GRPCServer) LeaveChat(ctx context.Context, req *chatMgmt.LeaveChatReque
 	}
 	response, err := s.service.GetChat(getReq)
 	if err != nil {
-		slog.Error("GetChat error", "error", err.Error())
-		return nil, err
+		// The chat is deleted once its last member leaves.
+		slog.Info("Left chat is no longer available", "chatId", req.ChatId, "error", err.Error())
+		return &chatMgmt.ChatRoomResponse{ChatId: req.ChatId}, nil
 	}
 	return &chatMgmt.ChatRoomResponse{
 		ChatId:          response.Chat.Id.String(),
@@ -275,6 +342,9 @@ func (s *GRPCServer) LeaveChat(ctx context.Context, req *chatMgmt.LeaveChatReque
 		ParticipantsIds: response.Users,
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 	}, nil
 }
 
@@ -291,7 +361,7 @@ func (s *GRPCServer) InviteUser(ctx context.Context, req *chatMgmt.InviteUserReq
 		slog.Error(err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	userId, err := uuid.Parse(req.RequestingUserId)
+	userId, err := uuid.Parse(req.UserId)
 	if err != nil {
 		slog.Error(err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -301,18 +371,11 @@ func (s *GRPCServer) InviteUser(ctx context.Context, req *chatMgmt.InviteUserReq
 		slog.Error(err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	adminReq := &dto.IsAdminRequest{
-		ChatId: chatId,
-		UserId: userId,
-	}
-	if isAdmin, err := s.service.IsAdmin(adminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return nil, status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
-	}
 
 	inviteReq := &dto.InviteUserRequest{
-		ChatId: chatId,
-		UserId: reqUserId,
+		ChatId:           chatId,
+		UserId:           userId,
+		RequestingUserId: reqUserId,
 	}
 	err = s.service.InviteUser(inviteReq)
 	if err != nil {
@@ -335,6 +398,9 @@ func (s *GRPCServer) InviteUser(ctx context.Context, req *chatMgmt.InviteUserReq
 		ParticipantsIds: response.Users,
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 	}, nil
 }
 
@@ -361,18 +427,11 @@ func (s *GRPCServer) KickUser(ctx context.Context, req *chatMgmt.KickUserRequest
 		slog.Error(err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	adminReq := &dto.IsAdminRequest{
-		ChatId: chatId,
-		UserId: userId,
-	}
-	if isAdmin, err := s.service.IsAdmin(adminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return nil, status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
-	}
 
 	kickReq := &dto.KickUserRequest{
-		ChatId: chatId,
-		UserId: reqUserId,
+		ChatId:           chatId,
+		UserId:           userId,
+		RequestingUserId: reqUserId,
 	}
 	err = s.service.KickUser(kickReq)
 	if err != nil {
@@ -395,10 +454,13 @@ func (s *GRPCServer) KickUser(ctx context.Context, req *chatMgmt.KickUserRequest
 		ParticipantsIds: response.Users,
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 	}, nil
 }
 
-func (s *GRPCServer) MakeAdmin(ctx context.Context, req *chatMgmt.MakeAdminRequest) (*chatMgmt.ChatRoomResponse, error) {
+func (s *GRPCServer) MakeChatAdmin(ctx context.Context, req *chatMgmt.MakeAdminRequest) (*chatMgmt.ChatRoomResponse, error) {
 	slog.Info("MakeAdmin controller started")
 	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
@@ -436,7 +498,7 @@ func (s *GRPCServer) MakeAdmin(ctx context.Context, req *chatMgmt.MakeAdminReque
 	getReq := &dto.GetChatRequest{
 		ChatId: chatId,
 	}
-	response, err := s.service.GetChatWithAdmins(getReq)
+	response, err := s.service.GetChat(getReq)
 	if err != nil {
 		slog.Error("GetChat error", "error", err.Error())
 		return nil, err
@@ -446,12 +508,16 @@ func (s *GRPCServer) MakeAdmin(ctx context.Context, req *chatMgmt.MakeAdminReque
 		CreatorId:       response.Chat.CreatorId.String(),
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 		ParticipantsIds: response.Users,
 		AdminsIds:       response.Admins,
+		Members:         mapChatMembers(response.Members),
 	}, nil
 }
 
-func (s *GRPCServer) DeleteAdmin(ctx context.Context, req *chatMgmt.DeleteAdminRequest) (*chatMgmt.ChatRoomResponse, error) {
+func (s *GRPCServer) DeleteChatAdmin(ctx context.Context, req *chatMgmt.DeleteAdminRequest) (*chatMgmt.ChatRoomResponse, error) {
 	slog.Info("DeleteAdmin controller started")
 	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
@@ -489,7 +555,7 @@ func (s *GRPCServer) DeleteAdmin(ctx context.Context, req *chatMgmt.DeleteAdminR
 	getReq := &dto.GetChatRequest{
 		ChatId: chatId,
 	}
-	response, err := s.service.GetChatWithAdmins(getReq)
+	response, err := s.service.GetChat(getReq)
 	if err != nil {
 		slog.Error("GetChat error", "error", err.Error())
 		return nil, err
@@ -499,12 +565,16 @@ func (s *GRPCServer) DeleteAdmin(ctx context.Context, req *chatMgmt.DeleteAdminR
 		CreatorId:       response.Chat.CreatorId.String(),
 		Name:            response.Chat.Name,
 		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
 		ParticipantsIds: response.Users,
 		AdminsIds:       response.Admins,
+		Members:         mapChatMembers(response.Members),
 	}, nil
 }
 
-func (s *GRPCServer) IsAdmin(ctx context.Context, req *chatMgmt.IsAdminRequest) (*chatMgmt.IsAdminResponse, error) {
+func (s *GRPCServer) IsChatAdmin(ctx context.Context, req *chatMgmt.IsAdminRequest) (*chatMgmt.IsAdminResponse, error) {
 	slog.Info("IsAdmin controller started")
 	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
@@ -537,10 +607,12 @@ func (s *GRPCServer) IsAdmin(ctx context.Context, req *chatMgmt.IsAdminRequest)
 
 func (s *GRPCServer) GetChat(ctx context.Context, req *chatMgmt.GetChatRequest) (*chatMgmt.ChatRoomResponse, error) {
 	slog.Info("GetChat controller started")
-	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
-	if err != nil {
-		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
-		return nil, err
+	if !s.authClient.IsServiceCall(ctx) {
+		_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+			return nil, err
+		}
 	}
 
 	chatId, err := uuid.Parse(req.ChatId)
@@ -561,7 +633,420 @@ func (s *GRPCServer) GetChat(ctx context.Context, req *chatMgmt.GetChatRequest)
 		CreatorId:       chat.Chat.CreatorId.String(),
 		Name:            chat.Chat.Name,
 		Description:     chat.Chat.Description,
+		IsDirect:        chat.Chat.IsDirect,
+		IsPrivate:       chat.Chat.IsPrivate,
+		MessageTtl:      chat.Chat.MessageTTL,
 		ParticipantsIds: chat.Users,
 		AdminsIds:       chat.Admins,
+		Members:         mapChatMembers(chat.Members),
+	}, nil
+}
+
+func (s *GRPCServer) ListUserChats(ctx context.Context, req *chatMgmt.ListUserChatsRequest) (*chatMgmt.ListUserChatsResponse, error) {
+	slog.Info("ListUserChats controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	listReq := &dto.ListUserChatsRequest{
+		UserId:    userId,
+		Limit:     int(req.Limit),
+		PageToken: req.PageToken,
+	}
+	listResp, err := s.service.ListUserChats(listReq)
+	if err != nil {
+		slog.Error("ListUserChats error", "error", err.Error())
+		return nil, err
+	}
+	chats := make([]*chatMgmt.ChatRoomResponse, len(listResp.Chats))
+	for i, chat := range listResp.Chats {
+		chats[i] = &chatMgmt.ChatRoomResponse{
+			ChatId:          chat.Chat.Id.String(),
+			CreatorId:       chat.Chat.CreatorId.String(),
+			Name:            chat.Chat.Name,
+			Description:     chat.Chat.Description,
+			IsDirect:        chat.Chat.IsDirect,
+			IsPrivate:       chat.Chat.IsPrivate,
+			MessageTtl:      chat.Chat.MessageTTL,
+			ParticipantsIds: chat.Users,
+		}
+	}
+	return &chatMgmt.ListUserChatsResponse{
+		Chats:         chats,
+		NextPageToken: listResp.NextPageToken,
+	}, nil
+}
+
+func (s *GRPCServer) SetChatMemberRole(ctx context.Context, req *chatMgmt.SetMemberRoleRequest) (*chatMgmt.ChatRoomResponse, error) {
+	slog.Info("SetChatMemberRole controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	chatId, err := uuid.Parse(req.ChatId)
+	if err != nil {
+		slog.Error("Invalid chat Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	targetUserId, err := uuid.Parse(req.TargetUserId)
+	if err != nil {
+		slog.Error("Invalid target user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	roleReq := &dto.SetMemberRoleRequest{
+		ChatId:       chatId,
+		UserId:       userId,
+		TargetUserId: targetUserId,
+		Role:         models.Role(req.Role),
+	}
+	err = s.service.SetMemberRole(roleReq)
+	if err != nil {
+		slog.Error("SetMemberRole error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("SetChatMemberRole controller successful", "chatId", req.ChatId, "targetUserId", req.TargetUserId)
+
+	getReq := &dto.GetChatRequest{
+		ChatId: chatId,
+	}
+	response, err := s.service.GetChat(getReq)
+	if err != nil {
+		slog.Error("GetChat error", "error", err.Error())
+		return nil, err
+	}
+	return &chatMgmt.ChatRoomResponse{
+		ChatId:          response.Chat.Id.String(),
+		CreatorId:       response.Chat.CreatorId.String(),
+		Name:            response.Chat.Name,
+		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
+		ParticipantsIds: response.Users,
+		AdminsIds:       response.Admins,
+		Members:         mapChatMembers(response.Members),
 	}, nil
 }
+
+func (s *GRPCServer) CheckChatPermission(ctx context.Context, req *chatMgmt.CheckPermissionRequest) (*chatMgmt.CheckPermissionResponse, error) {
+	slog.Info("CheckChatPermission controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	chatId, err := uuid.Parse(req.ChatId)
+	if err != nil {
+		slog.Error("Invalid chat Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	checkReq := &dto.CheckPermissionRequest{
+		ChatId:     chatId,
+		UserId:     userId,
+		Permission: models.Permission(req.Permission),
+	}
+	allowed, err := s.service.CheckPermission(checkReq)
+	if err != nil {
+		slog.Error("CheckPermission error", "error", err.Error())
+		return nil, err
+	}
+	return &chatMgmt.CheckPermissionResponse{Allowed: allowed}, nil
+}
+
+func (s *GRPCServer) TransferOwnership(ctx context.Context, req *chatMgmt.TransferOwnershipRequest) (*chatMgmt.ChatRoomResponse, error) {
+	slog.Info("TransferOwnership controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	chatId, err := uuid.Parse(req.ChatId)
+	if err != nil {
+		slog.Error("Invalid chat Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	newOwnerId, err := uuid.Parse(req.NewOwnerId)
+	if err != nil {
+		slog.Error("Invalid new owner Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	transferReq := &dto.TransferOwnershipRequest{
+		ChatId:     chatId,
+		UserId:     userId,
+		NewOwnerId: newOwnerId,
+	}
+	err = s.service.TransferOwnership(transferReq)
+	if err != nil {
+		slog.Error("TransferOwnership error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("TransferOwnership controller successful", "chatId", req.ChatId, "newOwnerId", req.NewOwnerId)
+
+	getReq := &dto.GetChatRequest{
+		ChatId: chatId,
+	}
+	response, err := s.service.GetChat(getReq)
+	if err != nil {
+		slog.Error("GetChat error", "error", err.Error())
+		return nil, err
+	}
+	return &chatMgmt.ChatRoomResponse{
+		ChatId:          response.Chat.Id.String(),
+		CreatorId:       response.Chat.CreatorId.String(),
+		Name:            response.Chat.Name,
+		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
+		ParticipantsIds: response.Users,
+		AdminsIds:       response.Admins,
+		Members:         mapChatMembers(response.Members),
+	}, nil
+}
+
+func (s *GRPCServer) CreateChatInvite(ctx context.Context, req *chatMgmt.CreateInviteRequest) (*chatMgmt.InviteResponse, error) {
+	slog.Info("CreateChatInvite controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	chatId, err := uuid.Parse(req.ChatId)
+	if err != nil {
+		slog.Error("Invalid chat Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	inviteReq := &dto.CreateInviteRequest{
+		ChatId:    chatId,
+		UserId:    userId,
+		ExpiresAt: req.ExpiresAt,
+		MaxUses:   int(req.MaxUses),
+	}
+	invite, err := s.service.CreateInvite(inviteReq)
+	if err != nil {
+		slog.Error("CreateInvite error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("CreateChatInvite controller successful", "chatId", req.ChatId)
+	return mapInvite(invite), nil
+}
+
+func (s *GRPCServer) RevokeChatInvite(ctx context.Context, req *chatMgmt.RevokeInviteRequest) (*chatMgmt.InviteResponse, error) {
+	slog.Info("RevokeChatInvite controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	revokeReq := &dto.RevokeInviteRequest{
+		Token:  req.Token,
+		UserId: userId,
+	}
+	invite, err := s.service.RevokeInvite(revokeReq)
+	if err != nil {
+		slog.Error("RevokeInvite error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("RevokeChatInvite controller successful", "chatId", invite.ChatId)
+	return mapInvite(invite), nil
+}
+
+func (s *GRPCServer) ListChatInvites(ctx context.Context, req *chatMgmt.ListInvitesRequest) (*chatMgmt.ListInvitesResponse, error) {
+	slog.Info("ListChatInvites controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	chatId, err := uuid.Parse(req.ChatId)
+	if err != nil {
+		slog.Error("Invalid chat Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	listReq := &dto.ListInvitesRequest{
+		ChatId: chatId,
+		UserId: userId,
+	}
+	invites, err := s.service.ListInvites(listReq)
+	if err != nil {
+		slog.Error("ListInvites error", "error", err.Error())
+		return nil, err
+	}
+	listResp := &chatMgmt.ListInvitesResponse{
+		Invites: make([]*chatMgmt.InviteResponse, len(invites)),
+	}
+	for i, invite := range invites {
+		listResp.Invites[i] = mapInvite(invite)
+	}
+	return listResp, nil
+}
+
+func (s *GRPCServer) JoinChatByInvite(ctx context.Context, req *chatMgmt.JoinByInviteRequest) (*chatMgmt.ChatRoomResponse, error) {
+	slog.Info("JoinChatByInvite controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	joinReq := &dto.JoinByInviteRequest{
+		Token:  req.Token,
+		UserId: userId,
+	}
+	chatId, err := s.service.JoinByInvite(joinReq)
+	if err != nil {
+		slog.Error("JoinByInvite error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("JoinChatByInvite controller successful", "chatId", chatId, "userId", req.UserId)
+
+	getReq := &dto.GetChatRequest{
+		ChatId: chatId,
+	}
+	response, err := s.service.GetChat(getReq)
+	if err != nil {
+		slog.Error("GetChat error", "error", err.Error())
+		return nil, err
+	}
+	return &chatMgmt.ChatRoomResponse{
+		ChatId:          response.Chat.Id.String(),
+		CreatorId:       response.Chat.CreatorId.String(),
+		Name:            response.Chat.Name,
+		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
+		ParticipantsIds: response.Users,
+		AdminsIds:       response.Admins,
+		Members:         mapChatMembers(response.Members),
+	}, nil
+}
+
+func (s *GRPCServer) SetChatMessageTTL(ctx context.Context, req *chatMgmt.SetMessageTTLRequest) (*chatMgmt.ChatRoomResponse, error) {
+	slog.Info("SetChatMessageTTL controller started")
+	_, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Authorization error: %v", err.Error()))
+		return nil, err
+	}
+
+	chatId, err := uuid.Parse(req.ChatId)
+	if err != nil {
+		slog.Error("Invalid chat Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	userId, err := uuid.Parse(req.UserId)
+	if err != nil {
+		slog.Error("Invalid user Id", "error", err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ttlReq := &dto.SetMessageTTLRequest{
+		ChatId:     chatId,
+		UserId:     userId,
+		MessageTTL: req.MessageTtl,
+	}
+	err = s.service.SetMessageTTL(ttlReq)
+	if err != nil {
+		slog.Error("SetMessageTTL error", "error", err.Error())
+		return nil, err
+	}
+	slog.Info("SetChatMessageTTL controller successful", "chatId", req.ChatId, "messageTtl", req.MessageTtl)
+
+	getReq := &dto.GetChatRequest{
+		ChatId: chatId,
+	}
+	response, err := s.service.GetChat(getReq)
+	if err != nil {
+		slog.Error("GetChat error", "error", err.Error())
+		return nil, err
+	}
+	return &chatMgmt.ChatRoomResponse{
+		ChatId:          response.Chat.Id.String(),
+		CreatorId:       response.Chat.CreatorId.String(),
+		Name:            response.Chat.Name,
+		Description:     response.Chat.Description,
+		IsDirect:        response.Chat.IsDirect,
+		IsPrivate:       response.Chat.IsPrivate,
+		MessageTtl:      response.Chat.MessageTTL,
+		ParticipantsIds: response.Users,
+		AdminsIds:       response.Admins,
+		Members:         mapChatMembers(response.Members),
+	}, nil
+}
+
+func mapInvite(invite dto.InviteResponse) *chatMgmt.InviteResponse {
+	return &chatMgmt.InviteResponse{
+		Token:     invite.Token,
+		ChatId:    invite.ChatId.String(),
+		CreatorId: invite.CreatorId.String(),
+		ExpiresAt: invite.ExpiresAt,
+		MaxUses:   int32(invite.MaxUses),
+		Uses:      int32(invite.Uses),
+		Revoked:   invite.Revoked,
+	}
+}
+
+func mapChatMembers(members []dto.ChatMember) []*chatMgmt.ChatMember {
+	chatMembers := make([]*chatMgmt.ChatMember, len(members))
+	for i, member := range members {
+		permissions := make([]string, len(member.Permissions))
+		for j, permission := range member.Permissions {
+			permissions[j] = string(permission)
+		}
+		chatMembers[i] = &chatMgmt.ChatMember{
+			UserId:      member.UserId.String(),
+			Role:        string(member.Role),
+			Permissions: permissions,
+		}
+	}
+	return chatMembers
+}
@@ -1,7 +1,15 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -10,6 +18,12 @@ import (
 	"example.com/chat-management/src/internal/models"
 	"example.com/chat-management/src/internal/repository"
 	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
 )
 
 type ChatManagementService struct {
@@ -25,34 +39,77 @@ func New(repo repository.ChatRepository) *ChatManagementService {
 func (s *ChatManagementService) GetChat(req *dto.GetChatRequest) (*dto.GetChatResponse, error) {
 	slog.Info("GetChat called", "chatID", req.ChatId)
 	chat, err := s.repo.FindById(req.ChatId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "chat not found")
+	}
 	if err != nil {
 		slog.Error("Failed to get chat", "error", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	users, err := s.repo.GetChatUsers(req.ChatId)
+	userChats, err := s.repo.GetChatMembers(req.ChatId)
 	if err != nil {
 		slog.Error("Failed to get chat users", "error", err.Error())
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if len(userChats) == 0 {
+		return nil, status.Error(codes.NotFound, "chat not found")
+	}
 	getResp := &dto.GetChatResponse{
-		Chat:  chat,
-		Users: users,
+		Chat:    chat,
+		Users:   make([]string, 0, len(userChats)),
+		Members: make([]dto.ChatMember, 0, len(userChats)),
+	}
+	for _, uc := range userChats {
+		getResp.Users = append(getResp.Users, uc.UserId.String())
+		if uc.Role.IsAdmin() {
+			getResp.Admins = append(getResp.Admins, uc.UserId.String())
+		}
+		getResp.Members = append(getResp.Members, dto.ChatMember{
+			UserId:      uc.UserId,
+			Role:        uc.Role,
+			Permissions: models.RolePermissions[uc.Role],
+		})
 	}
 	return getResp, nil
 }
 
-func (s *ChatManagementService) GetChatWithAdmins(req *dto.GetChatRequest) (*dto.GetChatResponse, error) {
-	getResp, err := s.GetChat(req)
+// authorize returns the role of userId in the chat when it grants permission.
+func (s *ChatManagementService) authorize(chatId uuid.UUID, userId uuid.UUID, permission models.Permission) (models.Role, error) {
+	role, err := s.repo.GetMemberRole(chatId, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Permission denied: not a member", "chatID", chatId, "userID", userId)
+		return "", status.Error(codes.PermissionDenied, "permission denied: not a member of the chat")
+	}
 	if err != nil {
-		return nil, err
+		slog.Error("Failed to get member role", "error", err.Error())
+		return "", status.Error(codes.Internal, err.Error())
 	}
-	admins, err := s.repo.GetChatAdmins(req.ChatId)
+	if !role.Can(permission) {
+		slog.Error("Permission denied", "chatID", chatId, "userID", userId, "role", role, "permission", permission)
+		return "", status.Errorf(codes.PermissionDenied, "permission denied: %s can not %s", role, permission)
+	}
+	return role, nil
+}
+
+// authorizeOver additionally requires the actor to outrank the target member.
+func (s *ChatManagementService) authorizeOver(chatId uuid.UUID, userId uuid.UUID, targetUserId uuid.UUID, permission models.Permission) (models.Role, error) {
+	role, err := s.authorize(chatId, userId, permission)
 	if err != nil {
-		slog.Error("Failed to get chat users", "error", err.Error())
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return "", err
 	}
-	getResp.Admins = admins
-	return getResp, nil
+	targetRole, err := s.repo.GetMemberRole(chatId, targetUserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", status.Error(codes.NotFound, "user is not a member of the chat")
+	}
+	if err != nil {
+		slog.Error("Failed to get member role", "error", err.Error())
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	if !role.Outranks(targetRole) {
+		slog.Error("Permission denied: target is not ranked below", "chatID", chatId, "userID", userId, "targetUserID", targetUserId)
+		return "", status.Errorf(codes.PermissionDenied, "permission denied: %s can not manage %s", role, targetRole)
+	}
+	return role, nil
 }
 
 func (s *ChatManagementService) CreateChat(req *dto.CreateChatRequest) (dto.ChatResponse, error) {
@@ -62,6 +119,7 @@ func (s *ChatManagementService) CreateChat(req *dto.CreateChatRequest) (dto.Chat
 		Name:        req.Name,
 		Description: req.Description,
 		CreatorId:   req.CreatorId,
+		IsPrivate:   req.IsPrivate,
 	}
 	err := s.repo.SaveChat(&chat)
 	if err != nil {
@@ -72,32 +130,68 @@ func (s *ChatManagementService) CreateChat(req *dto.CreateChatRequest) (dto.Chat
 	userChat := models.UserChat{
 		ChatId: chat.Id,
 		UserId: req.CreatorId,
+		Role:   models.RoleOwner,
 	}
 	err = s.repo.AddUserToChat(&userChat)
 	if err != nil {
 		slog.Error("Failed to add user to chat", "error", err.Error())
 		return dto.ChatResponse{}, status.Error(codes.InvalidArgument, err.Error())
 	}
-	admin := models.Admin{
-		ChatId: chat.Id,
-		UserId: req.CreatorId,
+
+	slog.Info("Chat created successfully", "chatID", chat.Id)
+	return dto.ChatResponse{ChatId: chat.Id.String()}, nil
+}
+
+// CreateDirectChat returns the one-to-one chat of the pair, creating it on
+// first use. Both users are plain members, direct chats have no admins and
+// can not be joined or invited to.
+func (s *ChatManagementService) CreateDirectChat(req *dto.CreateDirectChatRequest) (dto.ChatResponse, error) {
+	slog.Info("CreateDirectChat called", "userID", req.UserId, "peerID", req.PeerId)
+	if req.UserId == req.PeerId {
+		return dto.ChatResponse{}, status.Error(codes.InvalidArgument, "can not create a direct chat with yourself")
+	}
+	firstUserId, secondUserId := req.UserId, req.PeerId
+	if strings.Compare(firstUserId.String(), secondUserId.String()) > 0 {
+		firstUserId, secondUserId = secondUserId, firstUserId
+	}
+
+	directChat, err := s.repo.FindDirectChat(firstUserId, secondUserId)
+	if err == nil {
+		return dto.ChatResponse{ChatId: directChat.ChatId.String()}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Failed to find direct chat", "error", err.Error())
+		return dto.ChatResponse{}, status.Error(codes.Internal, err.Error())
 	}
-	err = s.repo.AddAdmin(&admin)
+
+	chat := models.Chat{
+		Id:        uuid.New(),
+		CreatorId: req.UserId,
+		IsDirect:  true,
+	}
+	directChat = &models.DirectChat{
+		ChatId:       chat.Id,
+		FirstUserId:  firstUserId,
+		SecondUserId: secondUserId,
+	}
+	err = s.repo.SaveDirectChat(&chat, directChat)
 	if err != nil {
-		slog.Error("Failed to add admin to chat", "error", err.Error())
+		// A concurrent request for the same pair may have won the unique index.
+		existing, findErr := s.repo.FindDirectChat(firstUserId, secondUserId)
+		if findErr == nil {
+			return dto.ChatResponse{ChatId: existing.ChatId.String()}, nil
+		}
+		slog.Error("Failed to create direct chat", "error", err.Error())
 		return dto.ChatResponse{}, status.Error(codes.InvalidArgument, err.Error())
 	}
-
-	slog.Info("Chat created successfully", "chatID", chat.Id)
+	slog.Info("Direct chat created successfully", "chatID", chat.Id)
 	return dto.ChatResponse{ChatId: chat.Id.String()}, nil
 }
 
 func (s *ChatManagementService) DeleteChat(req *dto.DeleteChatRequest) error {
 	slog.Info("DeleteChat called", "chatID", req.ChatId, "userID", req.UserId)
-	isAdminReq := &dto.IsAdminRequest{ChatId: req.ChatId, UserId: req.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	if _, err := s.authorize(req.ChatId, req.UserId, models.PermissionDeleteChat); err != nil {
+		return err
 	}
 
 	err := s.repo.DeleteChat(req.ChatId)
@@ -106,15 +200,33 @@ func (s *ChatManagementService) DeleteChat(req *dto.DeleteChatRequest) error {
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	slog.Info("Chat deleted successfully", "chatID", req.ChatId)
+	s.publishChatDeleted(req.ChatId, req.UserId)
 	return nil
 }
 
+// publishChatDeleted notifies chat-app and media-handler to purge the chat's
+// messages and attachments. The chat is already gone at this point, so a
+// failed publish is only logged.
+func (s *ChatManagementService) publishChatDeleted(chatId uuid.UUID, deletedBy uuid.UUID) {
+	event := models.ChatDeletedEvent{
+		ChatId:    chatId,
+		DeletedBy: deletedBy,
+		DeletedAt: uint64(time.Now().UnixMilli()),
+	}
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("Failed to marshal chat deleted event", "error", err.Error())
+		return
+	}
+	if err := s.repo.PublishInChatDeletedChannel(bytes); err != nil {
+		slog.Error("Failed to publish chat deleted event", "chatID", chatId, "error", err.Error())
+	}
+}
+
 func (s *ChatManagementService) UpdateChat(req *dto.UpdateChatRequest) error {
 	slog.Info("UpdateChat called", "chatID", req.ChatId, "name", req.Name, "description", req.Description, "userID", req.UserId)
-	isAdminReq := &dto.IsAdminRequest{ChatId: req.ChatId, UserId: req.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	if _, err := s.authorize(req.ChatId, req.UserId, models.PermissionEditInfo); err != nil {
+		return err
 	}
 	err := s.repo.UpdateChat(req)
 	if err != nil {
@@ -125,13 +237,52 @@ func (s *ChatManagementService) UpdateChat(req *dto.UpdateChatRequest) error {
 	return nil
 }
 
+// SetMessageTTL changes how long new messages of the chat live. Members
+// allowed to edit the chat info may change it; zero turns it off.
+func (s *ChatManagementService) SetMessageTTL(req *dto.SetMessageTTLRequest) error {
+	slog.Info("SetMessageTTL called", "chatID", req.ChatId, "userID", req.UserId, "messageTTL", req.MessageTTL)
+	if req.MessageTTL > models.MaxMessageTTL {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("message ttl can not exceed %d seconds", models.MaxMessageTTL))
+	}
+	if _, err := s.authorize(req.ChatId, req.UserId, models.PermissionEditInfo); err != nil {
+		return err
+	}
+	err := s.repo.SetMessageTTL(req.ChatId, req.MessageTTL)
+	if err != nil {
+		slog.Error("Failed to set message ttl", "error", err.Error())
+		return status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Message ttl set successfully", "chatID", req.ChatId)
+	return nil
+}
+
 func (s *ChatManagementService) JoinChat(req *dto.JoinChatRequest) error {
 	slog.Info("JoinChat called", "chatID", req.ChatId, "userID", req.UserId)
+	chat, err := s.repo.FindById(req.ChatId)
+	if err != nil {
+		slog.Error("Failed to get chat", "error", err.Error())
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if chat.IsDirect {
+		slog.Error("Direct chats can not be joined", "chatID", req.ChatId)
+		return status.Error(codes.NotFound, "chat not found")
+	}
+	if chat.IsPrivate {
+		slog.Error("Private chats can only be joined by invite", "chatID", req.ChatId)
+		return status.Error(codes.PermissionDenied, "chat is private, join it by invite")
+	}
+	if err := s.ensureNotMember(req.ChatId, req.UserId); err != nil {
+		return err
+	}
 	userChat := models.UserChat{
 		ChatId: req.ChatId,
 		UserId: req.UserId,
+		Role:   models.RoleMember,
+	}
+	err = s.repo.AddUserToChat(&userChat)
+	if repository.IsUniqueViolation(err) {
+		return status.Error(codes.AlreadyExists, "user is already a member of the chat")
 	}
-	err := s.repo.AddUserToChat(&userChat)
 	if err != nil {
 		slog.Error("Failed to add user to chat", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
@@ -140,111 +291,435 @@ func (s *ChatManagementService) JoinChat(req *dto.JoinChatRequest) error {
 	return nil
 }
 
+func (s *ChatManagementService) ensureNotMember(chatId uuid.UUID, userId uuid.UUID) error {
+	_, err := s.repo.GetMemberRole(chatId, userId)
+	if err == nil {
+		return status.Error(codes.AlreadyExists, "user is already a member of the chat")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Failed to get member role", "error", err.Error())
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
 func (s *ChatManagementService) LeaveChat(req *dto.LeaveChatRequest) error {
 	slog.Info("LeaveChat called", "chatID", req.ChatId, "userID", req.UserId)
 	userChat := models.UserChat{
 		ChatId: req.ChatId,
 		UserId: req.UserId,
 	}
-	err := s.repo.RemoveUserFromChat(&userChat)
+	promoted, remaining, err := s.repo.RemoveUserFromChat(&userChat)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "user is not a member of the chat")
+	}
 	if err != nil {
 		slog.Error("Failed to remove user from chat", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	slog.Info("User left chat successfully", "chatID", req.ChatId, "userID", req.UserId)
+	if promoted != uuid.Nil {
+		slog.Info("Ownership passed on to successor", "chatID", req.ChatId, "userID", promoted)
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	// Nobody is left to use or manage the chat.
+	err = s.repo.DeleteChat(req.ChatId)
+	if err != nil {
+		slog.Error("Failed to delete empty chat", "chatID", req.ChatId, "error", err.Error())
+		return nil
+	}
+	slog.Info("Empty chat deleted", "chatID", req.ChatId)
+	s.publishChatDeleted(req.ChatId, req.UserId)
+	return nil
+}
+
+// TransferOwnership hands the chat over to NewOwnerId. Only the owner may do
+// this, and they stay in the chat as an admin.
+func (s *ChatManagementService) TransferOwnership(req *dto.TransferOwnershipRequest) error {
+	slog.Info("TransferOwnership called", "chatID", req.ChatId, "userID", req.UserId, "newOwnerID", req.NewOwnerId)
+	if req.UserId == req.NewOwnerId {
+		return status.Error(codes.InvalidArgument, "user already owns the chat")
+	}
+	role, err := s.repo.GetMemberRole(req.ChatId, req.UserId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Failed to get member role", "error", err.Error())
+		return status.Error(codes.Internal, err.Error())
+	}
+	if role != models.RoleOwner {
+		slog.Error("Permission denied: not the owner", "chatID", req.ChatId, "userID", req.UserId)
+		return status.Error(codes.PermissionDenied, "permission denied: only the owner can transfer ownership")
+	}
+	err = s.repo.TransferOwnership(req.ChatId, req.UserId, req.NewOwnerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "user is not a member of the chat")
+	}
+	if err != nil {
+		slog.Error("Failed to transfer ownership", "error", err.Error())
+		return status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Ownership transferred successfully", "chatID", req.ChatId, "userID", req.NewOwnerId)
 	return nil
 }
 
+// InviteUser adds RequestingUserId to the chat on behalf of UserId.
 func (s *ChatManagementService) InviteUser(req *dto.InviteUserRequest) error {
-	slog.Info("InviteUser called", "chatID", req.ChatId, "userID", req.UserId)
-	isAdminReq := &dto.IsAdminRequest{ChatId: req.ChatId, UserId: req.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	slog.Info("InviteUser called", "chatID", req.ChatId, "userID", req.UserId, "invitedUserID", req.RequestingUserId)
+	if _, err := s.authorize(req.ChatId, req.UserId, models.PermissionInvite); err != nil {
+		return err
+	}
+	chat, err := s.repo.FindById(req.ChatId)
+	if err != nil {
+		slog.Error("Failed to get chat", "error", err.Error())
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if chat.IsDirect {
+		slog.Error("Can not invite to a direct chat", "chatID", req.ChatId)
+		return status.Error(codes.PermissionDenied, "can not invite to a direct chat")
+	}
+	if err := s.ensureNotMember(req.ChatId, req.RequestingUserId); err != nil {
+		return err
 	}
 	userChat := models.UserChat{
 		ChatId: req.ChatId,
 		UserId: req.RequestingUserId,
+		Role:   models.RoleMember,
+	}
+	err = s.repo.AddUserToChat(&userChat)
+	if repository.IsUniqueViolation(err) {
+		return status.Error(codes.AlreadyExists, "user is already a member of the chat")
 	}
-	err := s.repo.AddUserToChat(&userChat)
 	if err != nil {
 		slog.Error("Failed to invite user", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
-	slog.Info("User invited successfully", "chatID", req.ChatId, "userID", req.UserId)
+	slog.Info("User invited successfully", "chatID", req.ChatId, "userID", req.RequestingUserId)
 	return nil
 }
 
+// KickUser removes RequestingUserId from the chat on behalf of UserId, who
+// must outrank them.
 func (s *ChatManagementService) KickUser(req *dto.KickUserRequest) error {
-	slog.Info("KickUser called", "chatID", req.ChatId, "userID", req.UserId)
-	isAdminReq := &dto.IsAdminRequest{ChatId: req.ChatId, UserId: req.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	slog.Info("KickUser called", "chatID", req.ChatId, "userID", req.UserId, "kickedUserID", req.RequestingUserId)
+	if _, err := s.authorizeOver(req.ChatId, req.UserId, req.RequestingUserId, models.PermissionKick); err != nil {
+		return err
 	}
 	userChat := models.UserChat{
 		ChatId: req.ChatId,
 		UserId: req.RequestingUserId,
 	}
-	err := s.repo.RemoveUserFromChat(&userChat)
+	_, _, err := s.repo.RemoveUserFromChat(&userChat)
 	if err != nil {
 		slog.Error("Failed to kick user", "error", err.Error())
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
-	slog.Info("User kicked successfully", "chatID", req.ChatId, "userID", req.UserId)
+	slog.Info("User kicked successfully", "chatID", req.ChatId, "userID", req.RequestingUserId)
 	return nil
 }
 
-func (s *ChatManagementService) MakeAdmin(req *dto.AdminRequest) error {
-	slog.Info("MakeAdmin called", "chatID", req.ChatId, "userID", req.UserId, "requestingUserId", req.RequestingUserId)
-	isAdminReq := &dto.IsAdminRequest{ChatId: req.ChatId, UserId: req.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+// SetMemberRole changes the role of TargetUserId. The actor must outrank both
+// the current and the new role of the target, so ownership can not be granted
+// this way.
+func (s *ChatManagementService) SetMemberRole(req *dto.SetMemberRoleRequest) error {
+	slog.Info("SetMemberRole called", "chatID", req.ChatId, "userID", req.UserId, "targetUserID", req.TargetUserId, "role", req.Role)
+	if !req.Role.IsValid() {
+		return status.Errorf(codes.InvalidArgument, "unknown role %q", req.Role)
+	}
+	role, err := s.authorizeOver(req.ChatId, req.UserId, req.TargetUserId, models.PermissionManageRoles)
+	if err != nil {
+		return err
 	}
-	admin := models.Admin{
-		ChatId: req.ChatId,
-		UserId: req.RequestingUserId,
+	if !role.Outranks(req.Role) {
+		slog.Error("Permission denied: role is not ranked below", "chatID", req.ChatId, "userID", req.UserId, "role", req.Role)
+		return status.Errorf(codes.PermissionDenied, "permission denied: %s can not grant %s", role, req.Role)
 	}
-	err := s.repo.AddAdmin(&admin)
+	err = s.repo.SetMemberRole(req.ChatId, req.TargetUserId, req.Role)
 	if err != nil {
-		slog.Error("Failed to add admin", "error", err.Error())
-		return status.Error(codes.InvalidArgument, err.Error())
+		slog.Error("Failed to set member role", "error", err.Error())
+		return status.Error(codes.Internal, err.Error())
 	}
-	slog.Info("Admin added successfully", "chatID", req.ChatId, "userID", req.UserId)
+	slog.Info("Member role set successfully", "chatID", req.ChatId, "userID", req.TargetUserId, "role", req.Role)
 	return nil
 }
 
+// MakeAdmin grants RequestingUserId the admin role on behalf of UserId.
+func (s *ChatManagementService) MakeAdmin(req *dto.AdminRequest) error {
+	return s.SetMemberRole(&dto.SetMemberRoleRequest{
+		ChatId:       req.ChatId,
+		UserId:       req.UserId,
+		TargetUserId: req.RequestingUserId,
+		Role:         models.RoleAdmin,
+	})
+}
+
+// DeleteAdmin demotes RequestingUserId to a plain member on behalf of UserId.
 func (s *ChatManagementService) DeleteAdmin(req *dto.AdminRequest) error {
-	slog.Info("DeleteAdmin called", "chatID", req.ChatId, "userID", req.UserId, "requestingUserId", req.RequestingUserId)
-	isAdminReq := &dto.IsAdminRequest{ChatId: req.ChatId, UserId: req.UserId}
-	if isAdmin, err := s.IsAdmin(isAdminReq); err != nil || !isAdmin {
-		slog.Error("Permission denied or error checking admin status", "error", err)
-		return status.Error(codes.PermissionDenied, "permission denied or error checking admin status")
+	return s.SetMemberRole(&dto.SetMemberRoleRequest{
+		ChatId:       req.ChatId,
+		UserId:       req.UserId,
+		TargetUserId: req.RequestingUserId,
+		Role:         models.RoleMember,
+	})
+}
+
+// IsAdmin reports whether the user is the owner or an admin of the chat.
+// Users outside the chat are not admins.
+func (s *ChatManagementService) IsAdmin(adminReq *dto.IsAdminRequest) (bool, error) {
+	slog.Info("IsAdmin called", "chatID", adminReq.ChatId, "userID", adminReq.UserId)
+	role, err := s.repo.GetMemberRole(adminReq.ChatId, adminReq.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
 	}
-	admin := models.Admin{
-		ChatId: req.ChatId,
-		UserId: req.RequestingUserId,
+	if err != nil {
+		slog.Error("Failed to check admin status", "error", err.Error())
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	return role.IsAdmin(), nil
+}
+
+// CheckPermission reports whether the user's role grants the permission.
+// Users outside the chat have no permissions.
+func (s *ChatManagementService) CheckPermission(req *dto.CheckPermissionRequest) (bool, error) {
+	slog.Info("CheckPermission called", "chatID", req.ChatId, "userID", req.UserId, "permission", req.Permission)
+	role, err := s.repo.GetMemberRole(req.ChatId, req.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
 	}
-	err := s.repo.RemoveAdmin(&admin)
 	if err != nil {
-		slog.Error("Failed to remove admin", "error", err.Error())
-		return status.Error(codes.InvalidArgument, err.Error())
+		slog.Error("Failed to get member role", "error", err.Error())
+		return false, status.Error(codes.Internal, err.Error())
 	}
-	slog.Info("Admin removed successfully", "chatID", req.ChatId, "userID", req.UserId)
-	return nil
+	return role.Can(req.Permission), nil
 }
 
-func (s *ChatManagementService) IsAdmin(adminReq *dto.IsAdminRequest) (bool, error) {
-	slog.Info("IsAdmin called", "chatID", adminReq.ChatId, "userID", adminReq.UserId)
-	admin := models.Admin{
-		ChatId: adminReq.ChatId,
-		UserId: adminReq.UserId,
+// ListUserChats pages through the chats of a user in the order they were
+// joined. The page token is the last membership id of the previous page.
+func (s *ChatManagementService) ListUserChats(req *dto.ListUserChatsRequest) (*dto.ListUserChatsResponse, error) {
+	slog.Info("ListUserChats called", "userID", req.UserId, "pageToken", req.PageToken)
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	var afterId uint64
+	if req.PageToken != "" {
+		var err error
+		afterId, err = strconv.ParseUint(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
 	}
-	isAdmin, err := s.repo.IsAdmin(&admin)
+
+	userChats, err := s.repo.GetUserChats(req.UserId, uint(afterId), limit+1)
 	if err != nil {
-		slog.Error("Failed to check admin status", "error", err.Error())
-		return false, status.Error(codes.InvalidArgument, err.Error())
+		slog.Error("Failed to get user chats", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	listResp := &dto.ListUserChatsResponse{}
+	if len(userChats) > limit {
+		userChats = userChats[:limit]
+		listResp.NextPageToken = strconv.FormatUint(uint64(userChats[limit-1].ID), 10)
+	}
+	if len(userChats) == 0 {
+		return listResp, nil
+	}
+
+	chatIds := make([]uuid.UUID, len(userChats))
+	for i, uc := range userChats {
+		chatIds[i] = uc.ChatId
+	}
+	chats, err := s.repo.FindByIds(chatIds)
+	if err != nil {
+		slog.Error("Failed to get chats", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	users, err := s.repo.GetUsersOfChats(chatIds)
+	if err != nil {
+		slog.Error("Failed to get chat users", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	chatsById := make(map[uuid.UUID]*models.Chat, len(chats))
+	for i := range chats {
+		chatsById[chats[i].Id] = &chats[i]
+	}
+	for _, chatId := range chatIds {
+		chat, ok := chatsById[chatId]
+		if !ok {
+			continue
+		}
+		listResp.Chats = append(listResp.Chats, &dto.GetChatResponse{
+			Chat:  chat,
+			Users: users[chatId],
+		})
+	}
+	return listResp, nil
+}
+
+func newInviteToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func inviteResponse(invite *models.Invite) dto.InviteResponse {
+	return dto.InviteResponse{
+		Token:     invite.Token,
+		ChatId:    invite.ChatId,
+		CreatorId: invite.CreatorId,
+		ExpiresAt: invite.ExpiresAt,
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		Revoked:   invite.Revoked,
+	}
+}
+
+// CreateInvite issues a shareable invite link on behalf of a member allowed
+// to invite. A zero ExpiresAt or MaxUses leaves the link unlimited.
+func (s *ChatManagementService) CreateInvite(req *dto.CreateInviteRequest) (dto.InviteResponse, error) {
+	slog.Info("CreateInvite called", "chatID", req.ChatId, "userID", req.UserId, "expiresAt", req.ExpiresAt, "maxUses", req.MaxUses)
+	if req.MaxUses < 0 {
+		return dto.InviteResponse{}, status.Error(codes.InvalidArgument, "max uses can not be negative")
+	}
+	if req.ExpiresAt != 0 && req.ExpiresAt <= uint64(time.Now().Unix()) {
+		return dto.InviteResponse{}, status.Error(codes.InvalidArgument, "expiry must be in the future")
+	}
+	if _, err := s.authorize(req.ChatId, req.UserId, models.PermissionInvite); err != nil {
+		return dto.InviteResponse{}, err
+	}
+	chat, err := s.repo.FindById(req.ChatId)
+	if err != nil {
+		slog.Error("Failed to get chat", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.NotFound, err.Error())
+	}
+	if chat.IsDirect {
+		return dto.InviteResponse{}, status.Error(codes.PermissionDenied, "can not invite to a direct chat")
+	}
+
+	token, err := newInviteToken()
+	if err != nil {
+		slog.Error("Failed to generate invite token", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	invite := models.Invite{
+		Token:     token,
+		ChatId:    req.ChatId,
+		CreatorId: req.UserId,
+		ExpiresAt: req.ExpiresAt,
+		MaxUses:   req.MaxUses,
+	}
+	err = s.repo.SaveInvite(&invite)
+	if err != nil {
+		slog.Error("Failed to save invite", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Invite created successfully", "chatID", req.ChatId, "inviteID", invite.ID)
+	return inviteResponse(&invite), nil
+}
+
+// RevokeInvite disables an invite link. Links can be revoked by their creator
+// and by the chat admins.
+func (s *ChatManagementService) RevokeInvite(req *dto.RevokeInviteRequest) (dto.InviteResponse, error) {
+	slog.Info("RevokeInvite called", "userID", req.UserId)
+	invite, err := s.repo.FindInvite(req.Token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.InviteResponse{}, status.Error(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		slog.Error("Failed to get invite", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if invite.CreatorId != req.UserId {
+		role, err := s.repo.GetMemberRole(invite.ChatId, req.UserId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Error("Failed to get member role", "error", err.Error())
+			return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+		}
+		if !role.IsAdmin() {
+			slog.Error("Permission denied: can not revoke invite", "chatID", invite.ChatId, "userID", req.UserId)
+			return dto.InviteResponse{}, status.Error(codes.PermissionDenied, "permission denied: only the creator or an admin can revoke an invite")
+		}
+	}
+	err = s.repo.RevokeInvite(invite)
+	if err != nil {
+		slog.Error("Failed to revoke invite", "error", err.Error())
+		return dto.InviteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	slog.Info("Invite revoked successfully", "chatID", invite.ChatId, "inviteID", invite.ID)
+	return inviteResponse(invite), nil
+}
+
+// ListInvites returns every invite link of the chat, revoked and expired ones
+// included, to members allowed to invite.
+func (s *ChatManagementService) ListInvites(req *dto.ListInvitesRequest) ([]dto.InviteResponse, error) {
+	slog.Info("ListInvites called", "chatID", req.ChatId, "userID", req.UserId)
+	if _, err := s.authorize(req.ChatId, req.UserId, models.PermissionInvite); err != nil {
+		return nil, err
+	}
+	invites, err := s.repo.GetChatInvites(req.ChatId)
+	if err != nil {
+		slog.Error("Failed to get invites", "error", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	invitesResp := make([]dto.InviteResponse, len(invites))
+	for i := range invites {
+		invitesResp[i] = inviteResponse(&invites[i])
+	}
+	return invitesResp, nil
+}
+
+// JoinByInvite adds the user to the chat of a valid invite and returns the
+// chat id. Members following a link again do not use it up.
+func (s *ChatManagementService) JoinByInvite(req *dto.JoinByInviteRequest) (uuid.UUID, error) {
+	slog.Info("JoinByInvite called", "userID", req.UserId)
+	invite, err := s.repo.FindInvite(req.Token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, status.Error(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		slog.Error("Failed to get invite", "error", err.Error())
+		return uuid.Nil, status.Error(codes.Internal, err.Error())
+	}
+	now := uint64(time.Now().Unix())
+	switch {
+	case invite.Revoked:
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite has been revoked")
+	case invite.IsExpired(now):
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite has expired")
+	case invite.IsExhausted():
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite has been used up")
+	}
+
+	err = s.ensureNotMember(invite.ChatId, req.UserId)
+	if status.Code(err) == codes.AlreadyExists {
+		return invite.ChatId, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	userChat := models.UserChat{
+		ChatId: invite.ChatId,
+		UserId: req.UserId,
+		Role:   models.RoleMember,
+	}
+	err = s.repo.JoinByInvite(invite, &userChat, now)
+	if repository.IsUniqueViolation(err) {
+		// Joined concurrently through another request.
+		return invite.ChatId, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Another request used up or revoked the invite in the meantime.
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "invite is no longer valid")
+	}
+	if err != nil {
+		slog.Error("Failed to join by invite", "error", err.Error())
+		return uuid.Nil, status.Error(codes.Internal, err.Error())
 	}
-	return isAdmin, nil
+	slog.Info("User joined chat by invite", "chatID", invite.ChatId, "userID", req.UserId, "inviteID", invite.ID)
+	return invite.ChatId, nil
 }
@@ -14,6 +14,7 @@ import (
 	"example.com/chat-management/src/internal/repository"
 	"example.com/chat-management/src/internal/server"
 	"example.com/chat-management/src/internal/service"
+	"example.com/chat-management/src/redis"
 	_ "github.com/lib/pq"
 )
 
@@ -26,8 +27,12 @@ func main() {
 	database.Init(cfg)
 	defer database.Close()
 
+	log.Info("Initializing redis connection")
+	redis.Init(cfg)
+	defer redis.Close()
+
 	log.Info("Creating repository")
-	repo := repository.NewChatRepository(database.DB)
+	repo := repository.NewChatRepository(database.DB, redis.RedisClient)
 
 	log.Info("Creating service")
 	service := service.New(*repo)
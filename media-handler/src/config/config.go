@@ -9,6 +9,8 @@ type Config struct {
 	App       AppConfig
 	Auth      AuthConfig
 	UserMgmt  UserMgmtConfig
+	ChatMgmt  ChatMgmtConfig
+	ChanMgmt  ChanMgmtConfig
 	Db        DbConfig
 	SeaweedFS SeaweedFSConfig
 	Redis     RedisConfig
@@ -29,6 +31,16 @@ type UserMgmtConfig struct {
 	UserMgmtPort string `env:"USER_MGMT_PORT"`
 }
 
+type ChatMgmtConfig struct {
+	ChatMgmtHost string `env:"CHAT_MGMT_HOST"`
+	ChatMgmtPort string `env:"CHAT_MGMT_PORT"`
+}
+
+type ChanMgmtConfig struct {
+	ChanMgmtHost string `env:"CHANNEL_MGMT_HOST"`
+	ChanMgmtPort string `env:"CHANNEL_MGMT_PORT"`
+}
+
 type DbConfig struct {
 	DatabaseName string `env:"DB_NAME"`
 	UserName     string `env:"DB_USER"`
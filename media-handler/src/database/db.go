@@ -37,7 +37,7 @@ func Init(cfg *config.Config) {
 		panic(err)
 	}
 
-	db.AutoMigrate(&models.Media{})
+	db.AutoMigrate(&models.Media{}, &models.MediaReference{})
 	DB = db
 	slog.Info("Connected to DB")
 }
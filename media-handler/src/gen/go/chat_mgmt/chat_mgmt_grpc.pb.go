@@ -33,6 +33,16 @@ type ChatManagementClient interface {
 	DeleteChatAdmin(ctx context.Context, in *DeleteAdminRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error)
 	IsChatAdmin(ctx context.Context, in *IsAdminRequest, opts ...grpc.CallOption) (*IsAdminResponse, error)
 	GetChat(ctx context.Context, in *GetChatRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error)
+	CreateDirectChat(ctx context.Context, in *CreateDirectChatRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error)
+	ListUserChats(ctx context.Context, in *ListUserChatsRequest, opts ...grpc.CallOption) (*ListUserChatsResponse, error)
+	SetChatMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error)
+	CheckChatPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error)
+	TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error)
+	CreateChatInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error)
+	RevokeChatInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error)
+	ListChatInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error)
+	JoinChatByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error)
+	SetChatMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error)
 }
 
 type chatManagementClient struct {
@@ -142,6 +152,96 @@ func (c *chatManagementClient) GetChat(ctx context.Context, in *GetChatRequest,
 	return out, nil
 }
 
+func (c *chatManagementClient) CreateDirectChat(ctx context.Context, in *CreateDirectChatRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error) {
+	out := new(ChatRoomResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/CreateDirectChat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) ListUserChats(ctx context.Context, in *ListUserChatsRequest, opts ...grpc.CallOption) (*ListUserChatsResponse, error) {
+	out := new(ListUserChatsResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/ListUserChats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) SetChatMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error) {
+	out := new(ChatRoomResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/SetChatMemberRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) CheckChatPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error) {
+	out := new(CheckPermissionResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/CheckChatPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error) {
+	out := new(ChatRoomResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/TransferOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) CreateChatInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error) {
+	out := new(InviteResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/CreateChatInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) RevokeChatInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error) {
+	out := new(InviteResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/RevokeChatInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) ListChatInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error) {
+	out := new(ListInvitesResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/ListChatInvites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) JoinChatByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error) {
+	out := new(ChatRoomResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/JoinChatByInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatManagementClient) SetChatMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*ChatRoomResponse, error) {
+	out := new(ChatRoomResponse)
+	err := c.cc.Invoke(ctx, "/chat_mgmt.ChatManagement/SetChatMessageTTL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatManagementServer is the server API for ChatManagement service.
 // All implementations must embed UnimplementedChatManagementServer
 // for forward compatibility
@@ -157,6 +257,16 @@ type ChatManagementServer interface {
 	DeleteChatAdmin(context.Context, *DeleteAdminRequest) (*ChatRoomResponse, error)
 	IsChatAdmin(context.Context, *IsAdminRequest) (*IsAdminResponse, error)
 	GetChat(context.Context, *GetChatRequest) (*ChatRoomResponse, error)
+	CreateDirectChat(context.Context, *CreateDirectChatRequest) (*ChatRoomResponse, error)
+	ListUserChats(context.Context, *ListUserChatsRequest) (*ListUserChatsResponse, error)
+	SetChatMemberRole(context.Context, *SetMemberRoleRequest) (*ChatRoomResponse, error)
+	CheckChatPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error)
+	TransferOwnership(context.Context, *TransferOwnershipRequest) (*ChatRoomResponse, error)
+	CreateChatInvite(context.Context, *CreateInviteRequest) (*InviteResponse, error)
+	RevokeChatInvite(context.Context, *RevokeInviteRequest) (*InviteResponse, error)
+	ListChatInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error)
+	JoinChatByInvite(context.Context, *JoinByInviteRequest) (*ChatRoomResponse, error)
+	SetChatMessageTTL(context.Context, *SetMessageTTLRequest) (*ChatRoomResponse, error)
 	mustEmbedUnimplementedChatManagementServer()
 }
 
@@ -197,6 +307,36 @@ func (UnimplementedChatManagementServer) IsChatAdmin(context.Context, *IsAdminRe
 func (UnimplementedChatManagementServer) GetChat(context.Context, *GetChatRequest) (*ChatRoomResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChat not implemented")
 }
+func (UnimplementedChatManagementServer) CreateDirectChat(context.Context, *CreateDirectChatRequest) (*ChatRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDirectChat not implemented")
+}
+func (UnimplementedChatManagementServer) ListUserChats(context.Context, *ListUserChatsRequest) (*ListUserChatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserChats not implemented")
+}
+func (UnimplementedChatManagementServer) SetChatMemberRole(context.Context, *SetMemberRoleRequest) (*ChatRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetChatMemberRole not implemented")
+}
+func (UnimplementedChatManagementServer) CheckChatPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckChatPermission not implemented")
+}
+func (UnimplementedChatManagementServer) TransferOwnership(context.Context, *TransferOwnershipRequest) (*ChatRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferOwnership not implemented")
+}
+func (UnimplementedChatManagementServer) CreateChatInvite(context.Context, *CreateInviteRequest) (*InviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChatInvite not implemented")
+}
+func (UnimplementedChatManagementServer) RevokeChatInvite(context.Context, *RevokeInviteRequest) (*InviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeChatInvite not implemented")
+}
+func (UnimplementedChatManagementServer) ListChatInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChatInvites not implemented")
+}
+func (UnimplementedChatManagementServer) JoinChatByInvite(context.Context, *JoinByInviteRequest) (*ChatRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinChatByInvite not implemented")
+}
+func (UnimplementedChatManagementServer) SetChatMessageTTL(context.Context, *SetMessageTTLRequest) (*ChatRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetChatMessageTTL not implemented")
+}
 func (UnimplementedChatManagementServer) mustEmbedUnimplementedChatManagementServer() {}
 
 // UnsafeChatManagementServer may be embedded to opt out of forward compatibility for this service.
@@ -408,6 +548,186 @@ func _ChatManagement_GetChat_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatManagement_CreateDirectChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDirectChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).CreateDirectChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/CreateDirectChat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).CreateDirectChat(ctx, req.(*CreateDirectChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_ListUserChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserChatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).ListUserChats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/ListUserChats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).ListUserChats(ctx, req.(*ListUserChatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_SetChatMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).SetChatMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/SetChatMemberRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).SetChatMemberRole(ctx, req.(*SetMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_CheckChatPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).CheckChatPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/CheckChatPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).CheckChatPermission(ctx, req.(*CheckPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_TransferOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).TransferOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/TransferOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).TransferOwnership(ctx, req.(*TransferOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_CreateChatInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).CreateChatInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/CreateChatInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).CreateChatInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_RevokeChatInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).RevokeChatInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/RevokeChatInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).RevokeChatInvite(ctx, req.(*RevokeInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_ListChatInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).ListChatInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/ListChatInvites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).ListChatInvites(ctx, req.(*ListInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_JoinChatByInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinByInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).JoinChatByInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/JoinChatByInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).JoinChatByInvite(ctx, req.(*JoinByInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatManagement_SetChatMessageTTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMessageTTLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatManagementServer).SetChatMessageTTL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_mgmt.ChatManagement/SetChatMessageTTL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatManagementServer).SetChatMessageTTL(ctx, req.(*SetMessageTTLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChatManagement_ServiceDesc is the grpc.ServiceDesc for ChatManagement service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -459,6 +779,46 @@ var ChatManagement_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChat",
 			Handler:    _ChatManagement_GetChat_Handler,
 		},
+		{
+			MethodName: "CreateDirectChat",
+			Handler:    _ChatManagement_CreateDirectChat_Handler,
+		},
+		{
+			MethodName: "ListUserChats",
+			Handler:    _ChatManagement_ListUserChats_Handler,
+		},
+		{
+			MethodName: "SetChatMemberRole",
+			Handler:    _ChatManagement_SetChatMemberRole_Handler,
+		},
+		{
+			MethodName: "CheckChatPermission",
+			Handler:    _ChatManagement_CheckChatPermission_Handler,
+		},
+		{
+			MethodName: "TransferOwnership",
+			Handler:    _ChatManagement_TransferOwnership_Handler,
+		},
+		{
+			MethodName: "CreateChatInvite",
+			Handler:    _ChatManagement_CreateChatInvite_Handler,
+		},
+		{
+			MethodName: "RevokeChatInvite",
+			Handler:    _ChatManagement_RevokeChatInvite_Handler,
+		},
+		{
+			MethodName: "ListChatInvites",
+			Handler:    _ChatManagement_ListChatInvites_Handler,
+		},
+		{
+			MethodName: "JoinChatByInvite",
+			Handler:    _ChatManagement_JoinChatByInvite_Handler,
+		},
+		{
+			MethodName: "SetChatMessageTTL",
+			Handler:    _ChatManagement_SetChatMessageTTL_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chat_mgmt/chat_mgmt.proto",
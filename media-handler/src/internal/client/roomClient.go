@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"example.com/media-handler/src/config"
+	chanMgmt "example.com/media-handler/src/gen/go/channel_mgmt"
+	chatMgmt "example.com/media-handler/src/gen/go/chat_mgmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RoomGRPCClient reads chats and channels to check who may attach files to
+// their messages.
+type RoomGRPCClient struct {
+	chatMgmtClient    chatMgmt.ChatManagementClient
+	channelMgmtClient chanMgmt.ChannelManagementClient
+}
+
+func NewRoomClient(cfg *config.Config) *RoomGRPCClient {
+	chatConn, err := grpc.NewClient(fmt.Sprintf("%s:%s", cfg.ChatMgmt.ChatMgmtHost, cfg.ChatMgmt.ChatMgmtPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic("failed to connect: " + err.Error())
+	}
+	channelConn, err := grpc.NewClient(fmt.Sprintf("%s:%s", cfg.ChanMgmt.ChanMgmtHost, cfg.ChanMgmt.ChanMgmtPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic("failed to connect: " + err.Error())
+	}
+	return &RoomGRPCClient{
+		chatMgmtClient:    chatMgmt.NewChatManagementClient(chatConn),
+		channelMgmtClient: chanMgmt.NewChannelManagementClient(channelConn),
+	}
+}
+
+// IsParticipant reports whether userId belongs to the chat or channel roomId.
+// It fails with an error IsRoomNotFound recognizes when there is no such room.
+func (c *RoomGRPCClient) IsParticipant(ctx context.Context, roomId string, accessToken string, refreshToken string, userId string) (bool, error) {
+	md := metadata.Pairs("authorization", accessToken)
+	md.Append("x-refresh-token", refreshToken)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	chat, err := c.chatMgmtClient.GetChat(ctx, &chatMgmt.GetChatRequest{ChatId: roomId, UserId: userId})
+	if err == nil {
+		return slices.Contains(chat.ParticipantsIds, userId), nil
+	}
+	if !IsRoomNotFound(err) {
+		return false, err
+	}
+	channel, err := c.channelMgmtClient.GetChannel(ctx, &chanMgmt.GetChannelRequest{ChannelId: roomId, UserId: userId})
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(channel.ParticipantsIds, userId), nil
+}
+
+func IsRoomNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -13,10 +14,11 @@ import (
 type MediaHandlerController struct {
 	mediaHandlerService *service.MediaHandlerService
 	authClient          *client.AuthGRPCClient
+	roomClient          *client.RoomGRPCClient
 }
 
-func New(mediaHandlerService *service.MediaHandlerService, authClient *client.AuthGRPCClient) *MediaHandlerController {
-	return &MediaHandlerController{mediaHandlerService: mediaHandlerService, authClient: authClient}
+func New(mediaHandlerService *service.MediaHandlerService, authClient *client.AuthGRPCClient, roomClient *client.RoomGRPCClient) *MediaHandlerController {
+	return &MediaHandlerController{mediaHandlerService: mediaHandlerService, authClient: authClient, roomClient: roomClient}
 }
 
 func (m *MediaHandlerController) UploadMediaHandler(w http.ResponseWriter, r *http.Request) {
@@ -32,12 +34,33 @@ func (m *MediaHandlerController) UploadMediaHandler(w http.ResponseWriter, r *ht
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	// Only members of the room may attach files to its messages.
+	chatRoomId, err := uuid.Parse(r.Header.Get("ChatRoomId"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("ChatRoomId header is required: %v", err), http.StatusBadRequest)
+		return
+	}
+	isParticipant, err := m.roomClient.IsParticipant(r.Context(), chatRoomId.String(), authResp.AccessToken, authResp.RefreshToken, authResp.UserId)
+	if err != nil {
+		slog.Error("Failed to check room participants", "error", err.Error())
+		if client.IsRoomNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !isParticipant {
+		slog.Error(fmt.Sprintf("Permission denied: %v is not a participant of %v", authResp.UserId, chatRoomId))
+		http.Error(w, "permission denied", http.StatusForbidden)
+		return
+	}
 	file, fileHeader, err := r.FormFile("file")
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	err = m.mediaHandlerService.UploadMedia(messageId, file, fileHeader)
+	err = m.mediaHandlerService.UploadMedia(messageId, chatRoomId, file, fileHeader)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
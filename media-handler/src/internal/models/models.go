@@ -7,6 +7,18 @@ import (
 type Media struct {
 	ID     uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
 	FileId string
+	// MessageId and ChatRoomId are set for message attachments only, so the
+	// files can be purged together with their chat or message.
+	MessageId  uuid.UUID `gorm:"type:uuid;index"`
+	ChatRoomId uuid.UUID `gorm:"type:uuid;index"`
+}
+
+// MediaReference records a message that shares the file of another message,
+// like a forwarded copy. A file is only deleted once no message refers to it.
+type MediaReference struct {
+	MediaId    uuid.UUID `gorm:"type:uuid;primary_key"`
+	MessageId  uuid.UUID `gorm:"type:uuid;primary_key;index"`
+	ChatRoomId uuid.UUID `gorm:"type:uuid;index"`
 }
 
 func New(id uuid.UUID, fileId string) *Media {
@@ -34,7 +46,35 @@ type UploadMediaRequest struct {
 	MessageId string `json:"messageId"`
 }
 
+// ChatDeletedEvent is published by chat-management once a chat is gone.
+// DeletedAt is in unix milliseconds.
+type ChatDeletedEvent struct {
+	ChatId    uuid.UUID `json:"chatId"`
+	DeletedBy uuid.UUID `json:"deletedBy"`
+	DeletedAt uint64    `json:"deletedAt"`
+}
+
+// MessageExpiredEvent is published by chat-app once a disappearing message
+// is gone.
+type MessageExpiredEvent struct {
+	MessageId  uuid.UUID `json:"messageId"`
+	ChatRoomId uuid.UUID `json:"chatRoomId"`
+	ExpiredAt  uint64    `json:"expiredAt"`
+}
+
+// MessageForwardedEvent is published by chat-app when a message with
+// attachments is forwarded. The copy shares the files of the original.
+type MessageForwardedEvent struct {
+	MessageId   uuid.UUID   `json:"messageId"`
+	ChatRoomId  uuid.UUID   `json:"chatRoomId"`
+	FileIds     []uuid.UUID `json:"fileIds"`
+	ForwardedAt uint64      `json:"forwardedAt"`
+}
+
 type MessageIdXFileId struct {
-	MessageId uuid.UUID `json:"messageId"`
-	FileId    uuid.UUID `json:"fileId"`
+	MessageId   uuid.UUID `json:"messageId"`
+	FileId      uuid.UUID `json:"fileId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"example.com/media-handler/src/internal/models"
 	"github.com/go-redis/redis/v8"
@@ -23,6 +24,31 @@ func (m *MediaHandlerRepository) PublishInFileLoadedChannel(message interface{})
 	return m.redis.Publish(context.Background(), "file-loaded-channel", message).Err()
 }
 
+func (m *MediaHandlerRepository) SubscribeToChatDeletedChannel() *redis.PubSub {
+	return m.redis.Subscribe(context.Background(), "chat-deleted-channel")
+}
+
+func (m *MediaHandlerRepository) SubscribeToMessageExpiredChannel() *redis.PubSub {
+	return m.redis.Subscribe(context.Background(), "message-expired-channel")
+}
+
+func (m *MediaHandlerRepository) SubscribeToMessageForwardedChannel() *redis.PubSub {
+	return m.redis.Subscribe(context.Background(), "message-forwarded-channel")
+}
+
+// SaveMessageAttachment adds a file to the attachments uploaded for a message.
+// chat-app collects them from the same hash, so uploads are not lost when they
+// finish before the message itself is sent.
+func (m *MediaHandlerRepository) SaveMessageAttachment(messageId uuid.UUID, fileId uuid.UUID, attachment interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("message-media:%s", messageId)
+	_, err := m.redis.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HSet(context.Background(), key, fileId.String(), attachment)
+		pipe.Expire(context.Background(), key, ttl)
+		return nil
+	})
+	return err
+}
+
 func (m *MediaHandlerRepository) CacheVolumeIp(volumeId string, volumeIp string) error {
 	_, err := m.redis.Set(context.Background(), fmt.Sprintf("VOLUME_%s", volumeId), volumeIp, 0).Result()
 	if err != nil {
@@ -47,6 +73,31 @@ func (m *MediaHandlerRepository) Save(media *models.Media) error {
 	return nil
 }
 
+func (m *MediaHandlerRepository) AttachToMessage(id uuid.UUID, messageId uuid.UUID, chatRoomId uuid.UUID) error {
+	return m.db.Model(&models.Media{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"message_id":   messageId,
+		"chat_room_id": chatRoomId,
+	}).Error
+}
+
+func (m *MediaHandlerRepository) FindByChatRoomId(chatRoomId uuid.UUID) ([]models.Media, error) {
+	var media []models.Media
+	err := m.db.Where("chat_room_id = ?", chatRoomId).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (m *MediaHandlerRepository) FindByMessageId(messageId uuid.UUID) ([]models.Media, error) {
+	var media []models.Media
+	err := m.db.Where("message_id = ?", messageId).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
 func (m *MediaHandlerRepository) FindById(id uuid.UUID) (*models.Media, error) {
 	var media models.Media
 	err := m.db.Debug().Where("id = ?", id).Find(&media).Error
@@ -56,6 +107,53 @@ func (m *MediaHandlerRepository) FindById(id uuid.UUID) (*models.Media, error) {
 	return &media, nil
 }
 
+// SaveReference records that a message shares a file. Every instance hears
+// the same event, so saving a reference twice is not an error.
+func (m *MediaHandlerRepository) SaveReference(reference *models.MediaReference) error {
+	return m.db.Exec(
+		"INSERT INTO media_references (media_id, message_id, chat_room_id) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+		reference.MediaId, reference.MessageId, reference.ChatRoomId,
+	).Error
+}
+
+func (m *MediaHandlerRepository) DeleteReferencesByMessageId(messageId uuid.UUID) error {
+	return m.db.Where("message_id = ?", messageId).Delete(&models.MediaReference{}).Error
+}
+
+func (m *MediaHandlerRepository) DeleteReferencesByChatRoomId(chatRoomId uuid.UUID) error {
+	return m.db.Where("chat_room_id = ?", chatRoomId).Delete(&models.MediaReference{}).Error
+}
+
+// HandOver makes one of the messages that still refer to a file its owner,
+// so the file is purged with that message instead. It reports false when no
+// other message refers to the file.
+func (m *MediaHandlerRepository) HandOver(id uuid.UUID) (bool, error) {
+	tx := m.db.Begin()
+	var reference models.MediaReference
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("media_id = ?", id).First(&reference).Error
+	if err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	err = tx.Model(&models.Media{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"message_id":   reference.MessageId,
+		"chat_room_id": reference.ChatRoomId,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	err = tx.Where("media_id = ? AND message_id = ?", reference.MediaId, reference.MessageId).Delete(&models.MediaReference{}).Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return true, tx.Commit().Error
+}
+
 func (m *MediaHandlerRepository) DeleteById(id uuid.UUID) error {
 	err := m.db.Debug().Where("id = ?", id).Delete(&models.Media{}).Error
 	if err != nil {
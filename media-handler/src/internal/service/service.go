@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,15 +11,44 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"example.com/media-handler/src/config"
 	"example.com/media-handler/src/internal/models"
 	"example.com/media-handler/src/internal/repository"
+	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 )
 
+// MessageMediaTTL is how long uploaded attachments wait for their message.
+const MessageMediaTTL = time.Hour
+
+// mediaRepository is the part of repository.MediaHandlerRepository the
+// service uses.
+type mediaRepository interface {
+	PublishInFileLoadedChannel(message interface{}) error
+	SubscribeToChatDeletedChannel() *redis.PubSub
+	SubscribeToMessageExpiredChannel() *redis.PubSub
+	SubscribeToMessageForwardedChannel() *redis.PubSub
+	SaveMessageAttachment(messageId uuid.UUID, fileId uuid.UUID, attachment interface{}, ttl time.Duration) error
+	CacheVolumeIp(volumeId string, volumeIp string) error
+	GetVolumeIp(volumeId string) (string, error)
+	Save(media *models.Media) error
+	AttachToMessage(id uuid.UUID, messageId uuid.UUID, chatRoomId uuid.UUID) error
+	FindByChatRoomId(chatRoomId uuid.UUID) ([]models.Media, error)
+	FindByMessageId(messageId uuid.UUID) ([]models.Media, error)
+	FindById(id uuid.UUID) (*models.Media, error)
+	SaveReference(reference *models.MediaReference) error
+	DeleteReferencesByMessageId(messageId uuid.UUID) error
+	DeleteReferencesByChatRoomId(chatRoomId uuid.UUID) error
+	HandOver(id uuid.UUID) (bool, error)
+	DeleteById(id uuid.UUID) error
+}
+
+var _ mediaRepository = (*repository.MediaHandlerRepository)(nil)
+
 type MediaHandlerService struct {
-	mediaHandlerRepository *repository.MediaHandlerRepository
+	mediaHandlerRepository mediaRepository
 	masterUrl              string
 }
 
@@ -39,21 +69,36 @@ func (m *MediaHandlerService) UpdateAvatar(file *os.File, fileName string) (uuid
 	return media.ID, nil
 }
 
-func (m *MediaHandlerService) UploadMedia(messageId uuid.UUID, file multipart.File, fileHeader *multipart.FileHeader) (err error) {
+// UploadMedia stores an attachment of messageId in the room chatRoomId, so
+// the file is purged together with the room.
+func (m *MediaHandlerService) UploadMedia(messageId uuid.UUID, chatRoomId uuid.UUID, file multipart.File, fileHeader *multipart.FileHeader) (err error) {
 	media, err := m.assignFileToSeaweedFS(file, fileHeader.Filename)
 	if err != nil {
 		slog.Error(err.Error())
 		return err
 	}
+	err = m.mediaHandlerRepository.AttachToMessage(media.ID, messageId, chatRoomId)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
 	mf := models.MessageIdXFileId{
-		MessageId: messageId,
-		FileId:    media.ID,
+		MessageId:   messageId,
+		FileId:      media.ID,
+		FileName:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        fileHeader.Size,
 	}
 	bytes, err := json.Marshal(mf)
 	if err != nil {
 		slog.Error(err.Error())
 		return err
 	}
+	err = m.mediaHandlerRepository.SaveMessageAttachment(messageId, media.ID, bytes, MessageMediaTTL)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
 	err = m.mediaHandlerRepository.PublishInFileLoadedChannel(bytes)
 	if err != nil {
 		slog.Error(err.Error())
@@ -101,6 +146,143 @@ func (m *MediaHandlerService) DeleteMedia(id uuid.UUID) error {
 	return nil
 }
 
+// ListenChatDeletedChannel removes the attachments of chats deleted in
+// chat-management from SeaweedFS and the database.
+func (m *MediaHandlerService) ListenChatDeletedChannel() {
+	subscriber := m.mediaHandlerRepository.SubscribeToChatDeletedChannel()
+	err := subscriber.Ping(context.Background())
+	if err != nil {
+		slog.Error("Not Available chat-deleted-channel")
+		return
+	}
+	slog.Info("Available chat-deleted-channel")
+	for {
+		channel := subscriber.Channel()
+		message := <-channel
+		event := &models.ChatDeletedEvent{}
+		err = json.Unmarshal([]byte(message.Payload), event)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		m.purgeChatMedia(event.ChatId)
+	}
+}
+
+func (m *MediaHandlerService) purgeChatMedia(chatRoomId uuid.UUID) {
+	err := m.mediaHandlerRepository.DeleteReferencesByChatRoomId(chatRoomId)
+	if err != nil {
+		slog.Error("Failed to drop media references of deleted chat", "chatID", chatRoomId, "error", err.Error())
+		return
+	}
+	media, err := m.mediaHandlerRepository.FindByChatRoomId(chatRoomId)
+	if err != nil {
+		slog.Error("Failed to find media of deleted chat", "chatID", chatRoomId, "error", err.Error())
+		return
+	}
+	for _, md := range media {
+		err = m.releaseMedia(md.ID)
+		if err != nil {
+			slog.Error("Failed to delete media of deleted chat", "chatID", chatRoomId, "mediaID", md.ID, "error", err.Error())
+		}
+	}
+	slog.Info("Purged media of deleted chat", "chatID", chatRoomId, "count", len(media))
+}
+
+// ListenMessageExpiredChannel removes the attachments of disappearing
+// messages reaped by chat-app from SeaweedFS and the database.
+func (m *MediaHandlerService) ListenMessageExpiredChannel() {
+	subscriber := m.mediaHandlerRepository.SubscribeToMessageExpiredChannel()
+	err := subscriber.Ping(context.Background())
+	if err != nil {
+		slog.Error("Not Available message-expired-channel")
+		return
+	}
+	slog.Info("Available message-expired-channel")
+	for {
+		channel := subscriber.Channel()
+		message := <-channel
+		event := &models.MessageExpiredEvent{}
+		err = json.Unmarshal([]byte(message.Payload), event)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		m.purgeMessageMedia(event.MessageId)
+	}
+}
+
+func (m *MediaHandlerService) purgeMessageMedia(messageId uuid.UUID) {
+	err := m.mediaHandlerRepository.DeleteReferencesByMessageId(messageId)
+	if err != nil {
+		slog.Error("Failed to drop media references of expired message", "messageID", messageId, "error", err.Error())
+		return
+	}
+	media, err := m.mediaHandlerRepository.FindByMessageId(messageId)
+	if err != nil {
+		slog.Error("Failed to find media of expired message", "messageID", messageId, "error", err.Error())
+		return
+	}
+	for _, md := range media {
+		err = m.releaseMedia(md.ID)
+		if err != nil {
+			slog.Error("Failed to delete media of expired message", "messageID", messageId, "mediaID", md.ID, "error", err.Error())
+		}
+	}
+	if len(media) > 0 {
+		slog.Info("Purged media of expired message", "messageID", messageId, "count", len(media))
+	}
+}
+
+// ListenMessageForwardedChannel records the messages forwarded by chat-app
+// that share the files of their original.
+func (m *MediaHandlerService) ListenMessageForwardedChannel() {
+	subscriber := m.mediaHandlerRepository.SubscribeToMessageForwardedChannel()
+	err := subscriber.Ping(context.Background())
+	if err != nil {
+		slog.Error("Not Available message-forwarded-channel")
+		return
+	}
+	slog.Info("Available message-forwarded-channel")
+	for {
+		channel := subscriber.Channel()
+		message := <-channel
+		event := &models.MessageForwardedEvent{}
+		err = json.Unmarshal([]byte(message.Payload), event)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		m.referenceForwardedMedia(event)
+	}
+}
+
+func (m *MediaHandlerService) referenceForwardedMedia(event *models.MessageForwardedEvent) {
+	for _, fileId := range event.FileIds {
+		err := m.mediaHandlerRepository.SaveReference(&models.MediaReference{
+			MediaId:    fileId,
+			MessageId:  event.MessageId,
+			ChatRoomId: event.ChatRoomId,
+		})
+		if err != nil {
+			slog.Error("Failed to save media reference of forwarded message", "messageID", event.MessageId, "mediaID", fileId, "error", err.Error())
+		}
+	}
+}
+
+// releaseMedia deletes a file whose message is gone, unless a forwarded copy
+// still refers to it. The copy then owns the file.
+func (m *MediaHandlerService) releaseMedia(id uuid.UUID) error {
+	handedOver, err := m.mediaHandlerRepository.HandOver(id)
+	if err != nil {
+		return err
+	}
+	if handedOver {
+		return nil
+	}
+	return m.DeleteMedia(id)
+}
+
 func (m *MediaHandlerService) lookUpForFileIdAndVolumeAddress(id uuid.UUID) (string, string, error) {
 	media, err := m.mediaHandlerRepository.FindById(id)
 	if err != nil {
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"example.com/media-handler/src/internal/models"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+// fakeRepository keeps media and references in memory. HandOver behaves like
+// the one of repository.MediaHandlerRepository.
+type fakeRepository struct {
+	mediaRepository
+	volumeIp   string
+	media      map[uuid.UUID]models.Media
+	references []models.MediaReference
+}
+
+func (f *fakeRepository) GetVolumeIp(volumeId string) (string, error) {
+	return f.volumeIp, nil
+}
+
+func (f *fakeRepository) FindById(id uuid.UUID) (*models.Media, error) {
+	media, ok := f.media[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &media, nil
+}
+
+func (f *fakeRepository) FindByChatRoomId(chatRoomId uuid.UUID) ([]models.Media, error) {
+	var found []models.Media
+	for _, media := range f.media {
+		if media.ChatRoomId == chatRoomId {
+			found = append(found, media)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeRepository) FindByMessageId(messageId uuid.UUID) ([]models.Media, error) {
+	var found []models.Media
+	for _, media := range f.media {
+		if media.MessageId == messageId {
+			found = append(found, media)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeRepository) DeleteById(id uuid.UUID) error {
+	delete(f.media, id)
+	return nil
+}
+
+func (f *fakeRepository) SaveReference(reference *models.MediaReference) error {
+	for _, saved := range f.references {
+		if saved.MediaId == reference.MediaId && saved.MessageId == reference.MessageId {
+			return nil
+		}
+	}
+	f.references = append(f.references, *reference)
+	return nil
+}
+
+func (f *fakeRepository) deleteReferences(keep func(models.MediaReference) bool) {
+	kept := f.references[:0]
+	for _, reference := range f.references {
+		if keep(reference) {
+			kept = append(kept, reference)
+		}
+	}
+	f.references = kept
+}
+
+func (f *fakeRepository) DeleteReferencesByMessageId(messageId uuid.UUID) error {
+	f.deleteReferences(func(reference models.MediaReference) bool { return reference.MessageId != messageId })
+	return nil
+}
+
+func (f *fakeRepository) DeleteReferencesByChatRoomId(chatRoomId uuid.UUID) error {
+	f.deleteReferences(func(reference models.MediaReference) bool { return reference.ChatRoomId != chatRoomId })
+	return nil
+}
+
+func (f *fakeRepository) HandOver(id uuid.UUID) (bool, error) {
+	for _, reference := range f.references {
+		if reference.MediaId != id {
+			continue
+		}
+		media := f.media[id]
+		media.MessageId = reference.MessageId
+		media.ChatRoomId = reference.ChatRoomId
+		f.media[id] = media
+		f.deleteReferences(func(other models.MediaReference) bool { return other != reference })
+		return true, nil
+	}
+	return false, nil
+}
+
+// volumeServer records the files SeaweedFS is asked to delete.
+type volumeServer struct {
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (v *volumeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodDelete {
+		v.mu.Lock()
+		v.deleted = append(v.deleted, strings.TrimPrefix(r.URL.Path, "/"))
+		v.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (v *volumeServer) deletions() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]string(nil), v.deleted...)
+}
+
+type room struct {
+	chatRoomId uuid.UUID
+	messageId  uuid.UUID
+}
+
+type purge struct {
+	room int
+	// byMessage purges the message of the room, like an expiry does,
+	// instead of the whole room.
+	byMessage bool
+	// wantOwner is the room whose message owns the file afterwards, -1 once
+	// the file is deleted.
+	wantOwner int
+}
+
+func TestPurgeSharedMedia(t *testing.T) {
+	// The file is attached to the message of room 0 and forwarded to the
+	// messages of the rooms listed in forwardedTo.
+	tests := []struct {
+		name        string
+		forwardedTo []int
+		purges      []purge
+	}{
+		{
+			name:   "not forwarded",
+			purges: []purge{{room: 0, wantOwner: -1}},
+		},
+		{
+			name:        "forwarded copy deleted first",
+			forwardedTo: []int{1},
+			purges:      []purge{{room: 1, wantOwner: 0}, {room: 0, wantOwner: -1}},
+		},
+		{
+			name:        "original deleted first",
+			forwardedTo: []int{1},
+			purges:      []purge{{room: 0, wantOwner: 1}, {room: 1, wantOwner: -1}},
+		},
+		{
+			name:        "both messages expired",
+			forwardedTo: []int{1},
+			purges:      []purge{{room: 0, byMessage: true, wantOwner: 1}, {room: 1, byMessage: true, wantOwner: -1}},
+		},
+		{
+			name:        "forwarded twice",
+			forwardedTo: []int{1, 2},
+			purges:      []purge{{room: 0, wantOwner: 1}, {room: 1, wantOwner: 2}, {room: 2, wantOwner: -1}},
+		},
+		{
+			name:        "forwarded twice, copies deleted first",
+			forwardedTo: []int{1, 2},
+			purges:      []purge{{room: 2, wantOwner: 0}, {room: 1, byMessage: true, wantOwner: 0}, {room: 0, wantOwner: -1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volume := &volumeServer{}
+			server := httptest.NewServer(volume)
+			defer server.Close()
+
+			rooms := make([]room, 3)
+			for i := range rooms {
+				rooms[i] = room{chatRoomId: uuid.New(), messageId: uuid.New()}
+			}
+			fileId := uuid.New()
+			repo := &fakeRepository{
+				volumeIp: strings.TrimPrefix(server.URL, "http://"),
+				media: map[uuid.UUID]models.Media{
+					fileId: {ID: fileId, FileId: "3,01637037d6", MessageId: rooms[0].messageId, ChatRoomId: rooms[0].chatRoomId},
+				},
+			}
+			service := &MediaHandlerService{mediaHandlerRepository: repo}
+			for _, to := range tt.forwardedTo {
+				service.referenceForwardedMedia(&models.MessageForwardedEvent{
+					MessageId:  rooms[to].messageId,
+					ChatRoomId: rooms[to].chatRoomId,
+					FileIds:    []uuid.UUID{fileId},
+				})
+			}
+
+			for i, p := range tt.purges {
+				if p.byMessage {
+					service.purgeMessageMedia(rooms[p.room].messageId)
+				} else {
+					service.purgeChatMedia(rooms[p.room].chatRoomId)
+				}
+
+				media, ok := repo.media[fileId]
+				if p.wantOwner < 0 {
+					if ok {
+						t.Fatalf("purge %d: file is still owned by %v, want deleted", i, media.MessageId)
+					}
+					if got := volume.deletions(); len(got) != 1 || got[0] != "3,01637037d6" {
+						t.Fatalf("purge %d: SeaweedFS deletions = %v, want the file once", i, got)
+					}
+					continue
+				}
+				if !ok {
+					t.Fatalf("purge %d: file deleted, want owned by room %d", i, p.wantOwner)
+				}
+				want := rooms[p.wantOwner]
+				if media.MessageId != want.messageId || media.ChatRoomId != want.chatRoomId {
+					t.Fatalf("purge %d: file owned by %v in %v, want room %d", i, media.MessageId, media.ChatRoomId, p.wantOwner)
+				}
+				if got := volume.deletions(); len(got) != 0 {
+					t.Fatalf("purge %d: SeaweedFS deletions = %v, want none", i, got)
+				}
+				for _, reference := range repo.references {
+					if reference.MessageId == want.messageId {
+						t.Fatalf("purge %d: owner %v still has a reference", i, want.messageId)
+					}
+				}
+			}
+		})
+	}
+}
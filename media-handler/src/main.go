@@ -26,13 +26,17 @@ func main() {
 	database.Init(cfg)
 	db := database.DB
 	authClient := client.New(cfg)
+	roomClient := client.NewRoomClient(cfg)
 	repository := repository.New(db, redisClient)
 	service := service.New(repository, cfg)
-	controller := controller.New(service, authClient)
+	controller := controller.New(service, authClient, roomClient)
 	httpServer := server.NewHttpServer(controller)
 	grpcServer := server.NewGRPCServer(service, authClient)
 	app := app.New(httpServer, grpcServer, cfg)
 	go app.MustRun()
+	go service.ListenChatDeletedChannel()
+	go service.ListenMessageExpiredChannel()
+	go service.ListenMessageForwardedChannel()
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
@@ -61,6 +62,7 @@ func (s *NotificationGRPCServer) Start(l net.Listener) error {
 	slog.Debug(l.Addr().String())
 	slog.Debug("Listening notification channel")
 	go s.ListenNotificationChannel()
+	go s.ListenJoinRequestDecidedChannel()
 	return s.gRPCServer.Serve(l)
 }
 
@@ -100,6 +102,38 @@ func (s *NotificationGRPCServer) ListenNotificationChannel() {
 	}
 }
 
+// ListenJoinRequestDecidedChannel tells users whether their request to join
+// a channel was approved or rejected.
+func (s *NotificationGRPCServer) ListenJoinRequestDecidedChannel() {
+	var subscriber = s.notificationService.SubscribeToRedisChannel("join-request-decided-channel")
+	err := subscriber.Ping(context.Background())
+	if err != nil {
+		slog.Error("Not Available join-request-decided-channel")
+		return
+	}
+	slog.Info("Available join-request-decided-channel")
+	for {
+		channel := subscriber.Channel()
+		message := <-channel
+		event := &models.JoinRequestDecidedEvent{}
+		err = json.Unmarshal([]byte(message.Payload), event)
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		err = s.notificationService.NotifyUsers(
+			[]uuid.UUID{event.UserId},
+			event.DecidedAt,
+			fmt.Sprintf("Your request to join the channel was %s", event.Status),
+			event.ChannelName,
+			"",
+		)
+		if err != nil {
+			slog.Error(err.Error())
+		}
+	}
+}
+
 func (s *NotificationGRPCServer) BindDeviceToUser(ctx context.Context, req *notification.BindDeviceRequest) (*notification.BindDeviceResponse, error) {
 	authResp, err := s.authClient.PerformAuthorize(ctx, nil, req.UserId)
 	if err != nil {
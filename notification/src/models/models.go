@@ -23,3 +23,14 @@ type Message struct {
 	CreatedAt  uint64
 	WithMedia  int
 }
+
+// JoinRequestDecidedEvent is published by channel-management once a request
+// to join an approval channel is approved or rejected.
+type JoinRequestDecidedEvent struct {
+	RequestId   uint      `json:"requestId"`
+	ChannelId   uuid.UUID `json:"channelId"`
+	ChannelName string    `json:"channelName"`
+	UserId      uuid.UUID `json:"userId"`
+	Status      string    `json:"status"`
+	DecidedAt   uint64    `json:"decidedAt"`
+}
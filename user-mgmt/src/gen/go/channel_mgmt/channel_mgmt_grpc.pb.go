@@ -33,6 +33,18 @@ type ChannelManagementClient interface {
 	DeleteChannelAdmin(ctx context.Context, in *DeleteAdminRequest, opts ...grpc.CallOption) (*ChannelResponse, error)
 	IsChannelAdmin(ctx context.Context, in *IsAdminRequest, opts ...grpc.CallOption) (*IsAdminResponse, error)
 	GetChannel(ctx context.Context, in *GetChannelRequest, opts ...grpc.CallOption) (*ChannelResponse, error)
+	ListUserChannels(ctx context.Context, in *ListUserChannelsRequest, opts ...grpc.CallOption) (*ListUserChannelsResponse, error)
+	SetChannelMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*ChannelResponse, error)
+	CheckChannelPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error)
+	CreateChannelInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error)
+	RevokeChannelInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error)
+	ListChannelInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error)
+	JoinChannelByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*ChannelResponse, error)
+	RequestJoinChannel(ctx context.Context, in *JoinChannelRequest, opts ...grpc.CallOption) (*JoinRequestResponse, error)
+	ListChannelJoinRequests(ctx context.Context, in *ListJoinRequestsRequest, opts ...grpc.CallOption) (*ListJoinRequestsResponse, error)
+	ApproveChannelJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*JoinRequestResponse, error)
+	RejectChannelJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*JoinRequestResponse, error)
+	SearchChannels(ctx context.Context, in *SearchChannelsRequest, opts ...grpc.CallOption) (*SearchChannelsResponse, error)
 }
 
 type channelManagementClient struct {
@@ -142,6 +154,114 @@ func (c *channelManagementClient) GetChannel(ctx context.Context, in *GetChannel
 	return out, nil
 }
 
+func (c *channelManagementClient) ListUserChannels(ctx context.Context, in *ListUserChannelsRequest, opts ...grpc.CallOption) (*ListUserChannelsResponse, error) {
+	out := new(ListUserChannelsResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/ListUserChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) SetChannelMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*ChannelResponse, error) {
+	out := new(ChannelResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/SetChannelMemberRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) CheckChannelPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error) {
+	out := new(CheckPermissionResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/CheckChannelPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) CreateChannelInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error) {
+	out := new(InviteResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/CreateChannelInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) RevokeChannelInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*InviteResponse, error) {
+	out := new(InviteResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/RevokeChannelInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) ListChannelInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error) {
+	out := new(ListInvitesResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/ListChannelInvites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) JoinChannelByInvite(ctx context.Context, in *JoinByInviteRequest, opts ...grpc.CallOption) (*ChannelResponse, error) {
+	out := new(ChannelResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/JoinChannelByInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) RequestJoinChannel(ctx context.Context, in *JoinChannelRequest, opts ...grpc.CallOption) (*JoinRequestResponse, error) {
+	out := new(JoinRequestResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/RequestJoinChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) ListChannelJoinRequests(ctx context.Context, in *ListJoinRequestsRequest, opts ...grpc.CallOption) (*ListJoinRequestsResponse, error) {
+	out := new(ListJoinRequestsResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/ListChannelJoinRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) ApproveChannelJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*JoinRequestResponse, error) {
+	out := new(JoinRequestResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/ApproveChannelJoinRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) RejectChannelJoinRequest(ctx context.Context, in *DecideJoinRequestRequest, opts ...grpc.CallOption) (*JoinRequestResponse, error) {
+	out := new(JoinRequestResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/RejectChannelJoinRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelManagementClient) SearchChannels(ctx context.Context, in *SearchChannelsRequest, opts ...grpc.CallOption) (*SearchChannelsResponse, error) {
+	out := new(SearchChannelsResponse)
+	err := c.cc.Invoke(ctx, "/channel_mgmt.ChannelManagement/SearchChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChannelManagementServer is the server API for ChannelManagement service.
 // All implementations must embed UnimplementedChannelManagementServer
 // for forward compatibility
@@ -157,6 +277,18 @@ type ChannelManagementServer interface {
 	DeleteChannelAdmin(context.Context, *DeleteAdminRequest) (*ChannelResponse, error)
 	IsChannelAdmin(context.Context, *IsAdminRequest) (*IsAdminResponse, error)
 	GetChannel(context.Context, *GetChannelRequest) (*ChannelResponse, error)
+	ListUserChannels(context.Context, *ListUserChannelsRequest) (*ListUserChannelsResponse, error)
+	SetChannelMemberRole(context.Context, *SetMemberRoleRequest) (*ChannelResponse, error)
+	CheckChannelPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error)
+	CreateChannelInvite(context.Context, *CreateInviteRequest) (*InviteResponse, error)
+	RevokeChannelInvite(context.Context, *RevokeInviteRequest) (*InviteResponse, error)
+	ListChannelInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error)
+	JoinChannelByInvite(context.Context, *JoinByInviteRequest) (*ChannelResponse, error)
+	RequestJoinChannel(context.Context, *JoinChannelRequest) (*JoinRequestResponse, error)
+	ListChannelJoinRequests(context.Context, *ListJoinRequestsRequest) (*ListJoinRequestsResponse, error)
+	ApproveChannelJoinRequest(context.Context, *DecideJoinRequestRequest) (*JoinRequestResponse, error)
+	RejectChannelJoinRequest(context.Context, *DecideJoinRequestRequest) (*JoinRequestResponse, error)
+	SearchChannels(context.Context, *SearchChannelsRequest) (*SearchChannelsResponse, error)
 	mustEmbedUnimplementedChannelManagementServer()
 }
 
@@ -197,6 +329,42 @@ func (UnimplementedChannelManagementServer) IsChannelAdmin(context.Context, *IsA
 func (UnimplementedChannelManagementServer) GetChannel(context.Context, *GetChannelRequest) (*ChannelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChannel not implemented")
 }
+func (UnimplementedChannelManagementServer) ListUserChannels(context.Context, *ListUserChannelsRequest) (*ListUserChannelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserChannels not implemented")
+}
+func (UnimplementedChannelManagementServer) SetChannelMemberRole(context.Context, *SetMemberRoleRequest) (*ChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetChannelMemberRole not implemented")
+}
+func (UnimplementedChannelManagementServer) CheckChannelPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckChannelPermission not implemented")
+}
+func (UnimplementedChannelManagementServer) CreateChannelInvite(context.Context, *CreateInviteRequest) (*InviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChannelInvite not implemented")
+}
+func (UnimplementedChannelManagementServer) RevokeChannelInvite(context.Context, *RevokeInviteRequest) (*InviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeChannelInvite not implemented")
+}
+func (UnimplementedChannelManagementServer) ListChannelInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChannelInvites not implemented")
+}
+func (UnimplementedChannelManagementServer) JoinChannelByInvite(context.Context, *JoinByInviteRequest) (*ChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinChannelByInvite not implemented")
+}
+func (UnimplementedChannelManagementServer) RequestJoinChannel(context.Context, *JoinChannelRequest) (*JoinRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestJoinChannel not implemented")
+}
+func (UnimplementedChannelManagementServer) ListChannelJoinRequests(context.Context, *ListJoinRequestsRequest) (*ListJoinRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChannelJoinRequests not implemented")
+}
+func (UnimplementedChannelManagementServer) ApproveChannelJoinRequest(context.Context, *DecideJoinRequestRequest) (*JoinRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveChannelJoinRequest not implemented")
+}
+func (UnimplementedChannelManagementServer) RejectChannelJoinRequest(context.Context, *DecideJoinRequestRequest) (*JoinRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectChannelJoinRequest not implemented")
+}
+func (UnimplementedChannelManagementServer) SearchChannels(context.Context, *SearchChannelsRequest) (*SearchChannelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchChannels not implemented")
+}
 func (UnimplementedChannelManagementServer) mustEmbedUnimplementedChannelManagementServer() {}
 
 // UnsafeChannelManagementServer may be embedded to opt out of forward compatibility for this service.
@@ -408,6 +576,222 @@ func _ChannelManagement_GetChannel_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChannelManagement_ListUserChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).ListUserChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/ListUserChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).ListUserChannels(ctx, req.(*ListUserChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_SetChannelMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).SetChannelMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/SetChannelMemberRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).SetChannelMemberRole(ctx, req.(*SetMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_CheckChannelPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).CheckChannelPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/CheckChannelPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).CheckChannelPermission(ctx, req.(*CheckPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_CreateChannelInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).CreateChannelInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/CreateChannelInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).CreateChannelInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_RevokeChannelInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).RevokeChannelInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/RevokeChannelInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).RevokeChannelInvite(ctx, req.(*RevokeInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_ListChannelInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).ListChannelInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/ListChannelInvites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).ListChannelInvites(ctx, req.(*ListInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_JoinChannelByInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinByInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).JoinChannelByInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/JoinChannelByInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).JoinChannelByInvite(ctx, req.(*JoinByInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_RequestJoinChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).RequestJoinChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/RequestJoinChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).RequestJoinChannel(ctx, req.(*JoinChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_ListChannelJoinRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).ListChannelJoinRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/ListChannelJoinRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).ListChannelJoinRequests(ctx, req.(*ListJoinRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_ApproveChannelJoinRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideJoinRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).ApproveChannelJoinRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/ApproveChannelJoinRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).ApproveChannelJoinRequest(ctx, req.(*DecideJoinRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_RejectChannelJoinRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideJoinRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).RejectChannelJoinRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/RejectChannelJoinRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).RejectChannelJoinRequest(ctx, req.(*DecideJoinRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelManagement_SearchChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelManagementServer).SearchChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/channel_mgmt.ChannelManagement/SearchChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelManagementServer).SearchChannels(ctx, req.(*SearchChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChannelManagement_ServiceDesc is the grpc.ServiceDesc for ChannelManagement service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -459,6 +843,54 @@ var ChannelManagement_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChannel",
 			Handler:    _ChannelManagement_GetChannel_Handler,
 		},
+		{
+			MethodName: "ListUserChannels",
+			Handler:    _ChannelManagement_ListUserChannels_Handler,
+		},
+		{
+			MethodName: "SetChannelMemberRole",
+			Handler:    _ChannelManagement_SetChannelMemberRole_Handler,
+		},
+		{
+			MethodName: "CheckChannelPermission",
+			Handler:    _ChannelManagement_CheckChannelPermission_Handler,
+		},
+		{
+			MethodName: "CreateChannelInvite",
+			Handler:    _ChannelManagement_CreateChannelInvite_Handler,
+		},
+		{
+			MethodName: "RevokeChannelInvite",
+			Handler:    _ChannelManagement_RevokeChannelInvite_Handler,
+		},
+		{
+			MethodName: "ListChannelInvites",
+			Handler:    _ChannelManagement_ListChannelInvites_Handler,
+		},
+		{
+			MethodName: "JoinChannelByInvite",
+			Handler:    _ChannelManagement_JoinChannelByInvite_Handler,
+		},
+		{
+			MethodName: "RequestJoinChannel",
+			Handler:    _ChannelManagement_RequestJoinChannel_Handler,
+		},
+		{
+			MethodName: "ListChannelJoinRequests",
+			Handler:    _ChannelManagement_ListChannelJoinRequests_Handler,
+		},
+		{
+			MethodName: "ApproveChannelJoinRequest",
+			Handler:    _ChannelManagement_ApproveChannelJoinRequest_Handler,
+		},
+		{
+			MethodName: "RejectChannelJoinRequest",
+			Handler:    _ChannelManagement_RejectChannelJoinRequest_Handler,
+		},
+		{
+			MethodName: "SearchChannels",
+			Handler:    _ChannelManagement_SearchChannels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "channel_mgmt/channel_mgmt.proto",